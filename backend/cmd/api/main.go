@@ -9,12 +9,17 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // часовые пояса расписаний серий без зависимости от системной tzdata
 
 	"moshosp/backend/internal/config"
 	"moshosp/backend/internal/db"
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/events"
 	"moshosp/backend/internal/handlers"
+	"moshosp/backend/internal/jobs"
 	"moshosp/backend/internal/repository"
 	"moshosp/backend/internal/repository/gamerepo"
+	"moshosp/backend/internal/repository/partnerrepo"
 	"moshosp/backend/internal/repository/requestrepo"
 	"moshosp/backend/internal/repository/userrepo"
 	"moshosp/backend/internal/services"
@@ -44,26 +49,82 @@ func main() {
 	userRepo := userrepo.NewUserRepository(database, logger)
 	gameRepo := gamerepo.NewGameRepository(database, logger)
 	requestRepo := requestrepo.NewRequestRepository(database, logger)
+	partnerRepo := partnerrepo.NewPartnerRepository(database, logger)
 
 	// Создаем общий репозиторий с интерфейсами
 	repo := &repository.Repository{
 		User:    userRepo,
 		Game:    gameRepo,
 		Request: requestRepo,
+		Partner: partnerRepo,
 	}
 
 	// Создаем сервисы
 	userService := services.NewUserService(repo, cfg.JWT)
 	gameService := services.NewGameService(repo, logger)
 	requestService := services.NewRequestService(repo, gameService, logger)
+	partnerService := services.NewPartnerService(repo, logger)
+	requestService.SetRecommendationWeights(models.RecommendationWeights{
+		Category: cfg.Recommendation.CategoryWeight,
+		Distance: cfg.Recommendation.DistanceWeight,
+		Rating:   cfg.Recommendation.RatingWeight,
+		Wait:     cfg.Recommendation.WaitWeight,
+		Priority: cfg.Recommendation.PriorityWeight,
+	})
+	requestService.SetModerationPolicy(models.ModerationPolicy{
+		Enabled:                  cfg.Moderation.Enabled,
+		TrustedCompletedRequests: cfg.Moderation.TrustedCompleted,
+		MaxRejectedRequests:      cfg.Moderation.MaxRejected,
+	})
+	requestService.SetRatingPrior(cfg.RatingPriorMean, cfg.RatingPriorWeight)
+	requestService.SetReportHideThreshold(cfg.ReportHideThreshold)
+	requestService.SetMaxVolunteerActiveRequests(cfg.MaxVolunteerActiveRequests)
+
+	// Поток событий для клиентов: уведомления, смены статусов и новые заявки
+	eventHub := events.NewHub(cfg.EventsHistorySize)
+	requestService.SetEventHub(eventHub)
+	gameService.SetEventHub(eventHub)
 
 	// Создаем обработчики
 	userHandler := handlers.NewUserHandler(userService)
 	gameHandler := handlers.NewGameHandler(gameService)
 	requestHandler := handlers.NewRequestHandler(repo, requestService, gameService, userService, logger)
+	partnerHandler := handlers.NewPartnerHandler(partnerService)
+	eventHandler := handlers.NewEventHandler(eventHub, cfg.JWT.Secret)
+
+	// Запускаем фоновые задачи
+	runner := jobs.NewRunner(logger)
+	if cfg.Scheduler.Enabled {
+		lookahead := time.Duration(cfg.Scheduler.SeriesLookaheadHours) * time.Hour
+		runner.Add("request_series", time.Duration(cfg.Scheduler.SeriesIntervalMinutes)*time.Minute, time.Minute,
+			func(ctx context.Context) error {
+				return requestService.GenerateSeriesOccurrences(ctx, lookahead)
+			})
+		runner.Add("request_expiry", time.Duration(cfg.Scheduler.ExpiryIntervalMinutes)*time.Minute, time.Minute,
+			requestService.ExpireOverdueRequests)
+		runner.Add("request_escalation", time.Duration(cfg.Scheduler.EscalationIntervalMinutes)*time.Minute, time.Minute,
+			func(ctx context.Context) error {
+				return requestService.EscalateOverdueRequests(ctx, cfg.Scheduler.EscalationMaxLevel)
+			})
+		runner.Add("stats_rollups", time.Duration(cfg.Scheduler.StatsIntervalMinutes)*time.Minute, 5*time.Minute,
+			func(ctx context.Context) error {
+				return requestService.RefreshStatsRollups(ctx, cfg.Scheduler.StatsLookbackDays)
+			})
+		runner.Start()
+	} else {
+		// Статистика читается только из сводок: без планировщика они пересчитываются
+		// хотя бы при запуске, иначе на новой базе статистика осталась бы пустой
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			if err := requestService.RefreshStatsRollups(ctx, cfg.Scheduler.StatsLookbackDays); err != nil {
+				logger.Error("Ошибка пересчета сводок статистики", "error", err)
+			}
+		}()
+	}
 
 	// Настраиваем маршрутизатор
-	router := handlers.SetupRouter(userHandler, gameHandler, requestHandler)
+	router := handlers.SetupRouter(userHandler, gameHandler, requestHandler, partnerHandler, eventHandler)
 
 	// Создаем HTTP-сервер
 	server := &http.Server{
@@ -71,6 +132,9 @@ func main() {
 		Handler: router,
 	}
 
+	// Shutdown не ждет открытые потоки событий: они завершаются закрытием Hub
+	server.RegisterOnShutdown(eventHub.Close)
+
 	// Запускаем сервер в отдельной горутине
 	go func() {
 		logger.Info("Запуск сервера", "port", cfg.Server.Port)
@@ -96,5 +160,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Дожидаемся завершения фоновых задач
+	runner.Stop()
+
 	logger.Info("Сервер успешно завершил работу")
 }
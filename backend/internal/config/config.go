@@ -21,6 +21,28 @@ type Config struct {
 	// Настройки JWT
 	JWT JWTConfig
 
+	// Настройки фоновых задач
+	Scheduler SchedulerConfig
+
+	// Веса сигналов рекомендаций заявок
+	Recommendation RecommendationConfig
+
+	// Правила проверки заявок перед публикацией
+	Moderation ModerationConfig
+
+	// Априорная оценка и ее вес в байесовском рейтинге пользователя
+	RatingPriorMean   float64
+	RatingPriorWeight float64
+
+	// Сколько открытых жалоб скрывает заявку или комментарий до решения администратора; 0 - не скрывать
+	ReportHideThreshold int
+
+	// Сколько незавершенных заявок координатор может назначить одному волонтеру
+	MaxVolunteerActiveRequests int
+
+	// Сколько последних событий потока хранится для переподключения клиентов
+	EventsHistorySize int
+
 	// Настройки метрик
 	MetricsEnabled bool
 	MetricsPath    string
@@ -48,6 +70,43 @@ type JWTConfig struct {
 	ExpiryHours int
 }
 
+// SchedulerConfig содержит настройки фоновых задач
+type SchedulerConfig struct {
+	Enabled bool
+	// SeriesIntervalMinutes - как часто проверять серии повторяющихся заявок
+	SeriesIntervalMinutes int
+	// SeriesLookaheadHours - за сколько часов до срока создается повторение серии
+	SeriesLookaheadHours int
+	// ExpiryIntervalMinutes - как часто искать заявки с истекшим сроком
+	ExpiryIntervalMinutes int
+	// EscalationIntervalMinutes - как часто искать заявки для эскалации
+	EscalationIntervalMinutes int
+	// EscalationMaxLevel - сколько раз может эскалироваться одна заявка
+	EscalationMaxLevel int
+	// StatsIntervalMinutes - как часто пересчитывать дневные сводки статистики
+	StatsIntervalMinutes int
+	// StatsLookbackDays - за сколько последних дней пересчитываются сводки
+	StatsLookbackDays int
+}
+
+// RecommendationConfig содержит веса сигналов в оценке заявок, рекомендованных волонтеру
+type RecommendationConfig struct {
+	CategoryWeight float64
+	DistanceWeight float64
+	RatingWeight   float64
+	WaitWeight     float64
+	PriorityWeight float64
+}
+
+// ModerationConfig содержит правила, по которым заявки публикуются без проверки
+type ModerationConfig struct {
+	Enabled bool
+	// TrustedCompleted - сколько выполненных заявок нужно автору для публикации без проверки
+	TrustedCompleted int
+	// MaxRejected - сколько отклоненных заявок допускается у автора, публикуемого без проверки
+	MaxRejected int
+}
+
 // Load загружает конфигурацию из переменных окружения
 func Load() (*Config, error) {
 	var cfg Config
@@ -128,6 +187,125 @@ func Load() (*Config, error) {
 		ExpiryHours: jwtExpiryHours,
 	}
 
+	// Настройки фоновых задач. Интервалы и периоды должны быть положительными:
+	// нулевой интервал остановил бы сервер паникой time.NewTicker при старте планировщика
+	cfg.Scheduler.Enabled, err = getEnvBool("SCHEDULER_ENABLED", true)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.SeriesIntervalMinutes, err = getEnvPositiveInt("SERIES_INTERVAL_MINUTES", 15)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.SeriesLookaheadHours, err = getEnvPositiveInt("SERIES_LOOKAHEAD_HOURS", 72)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.ExpiryIntervalMinutes, err = getEnvPositiveInt("EXPIRY_INTERVAL_MINUTES", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.EscalationIntervalMinutes, err = getEnvPositiveInt("ESCALATION_INTERVAL_MINUTES", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.EscalationMaxLevel, err = getEnvInt("ESCALATION_MAX_LEVEL", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.StatsIntervalMinutes, err = getEnvPositiveInt("STATS_INTERVAL_MINUTES", 15)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Scheduler.StatsLookbackDays, err = getEnvPositiveInt("STATS_LOOKBACK_DAYS", 7)
+	if err != nil {
+		return nil, err
+	}
+
+	// Веса сигналов рекомендаций заявок
+	cfg.Recommendation.CategoryWeight, err = getEnvFloat("RECOMMENDATION_CATEGORY_WEIGHT", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Recommendation.DistanceWeight, err = getEnvFloat("RECOMMENDATION_DISTANCE_WEIGHT", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Recommendation.RatingWeight, err = getEnvFloat("RECOMMENDATION_RATING_WEIGHT", 1)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Recommendation.WaitWeight, err = getEnvFloat("RECOMMENDATION_WAIT_WEIGHT", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Recommendation.PriorityWeight, err = getEnvFloat("RECOMMENDATION_PRIORITY_WEIGHT", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Правила проверки заявок
+	cfg.Moderation.Enabled, err = getEnvBool("MODERATION_ENABLED", true)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Moderation.TrustedCompleted, err = getEnvInt("MODERATION_TRUSTED_COMPLETED", 2)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Moderation.MaxRejected, err = getEnvInt("MODERATION_MAX_REJECTED", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Рейтинг пользователей
+	cfg.RatingPriorMean, err = getEnvFloat("RATING_PRIOR_MEAN", 4)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RatingPriorMean < 1 || cfg.RatingPriorMean > 5 {
+		return nil, errors.New("RATING_PRIOR_MEAN должна быть от 1 до 5")
+	}
+
+	cfg.RatingPriorWeight, err = getEnvFloat("RATING_PRIOR_WEIGHT", 5)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RatingPriorWeight < 0 {
+		return nil, errors.New("RATING_PRIOR_WEIGHT не может быть отрицательной")
+	}
+
+	// Жалобы
+	cfg.ReportHideThreshold, err = getEnvInt("REPORT_HIDE_THRESHOLD", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	// Назначение волонтеров координатором
+	cfg.MaxVolunteerActiveRequests, err = getEnvPositiveInt("MAX_VOLUNTEER_ACTIVE_REQUESTS", 5)
+	if err != nil {
+		return nil, err
+	}
+
+	// Поток событий
+	cfg.EventsHistorySize, err = getEnvInt("EVENTS_HISTORY_SIZE", 1000)
+	if err != nil {
+		return nil, err
+	}
+
 	// Настройки метрик
 	cfg.MetricsEnabled, err = getEnvBool("METRICS_ENABLED", true)
 	if err != nil {
@@ -166,6 +344,34 @@ func getEnvInt(key string, defaultValue int) (int, error) {
 	return value, nil
 }
 
+// getEnvPositiveInt преобразует значение переменной окружения в int и проверяет, что оно больше нуля
+func getEnvPositiveInt(key string, defaultValue int) (int, error) {
+	value, err := getEnvInt(key, defaultValue)
+	if err != nil {
+		return 0, err
+	}
+	if value <= 0 {
+		return 0, errors.New("переменная " + key + " должна быть больше нуля")
+	}
+
+	return value, nil
+}
+
+// getEnvFloat преобразует строковое значение переменной окружения в float64
+func getEnvFloat(key string, defaultValue float64) (float64, error) {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue, nil
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0, errors.New("неверный формат переменной " + key)
+	}
+
+	return value, nil
+}
+
 // getEnvBool преобразует строковое значение переменной окружения в bool
 func getEnvBool(key string, defaultValue bool) (bool, error) {
 	valueStr := os.Getenv(key)
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+)
+
+// AbuseReportTarget представляет тип объекта жалобы
+type AbuseReportTarget string
+
+// Константы для типов объектов жалобы
+const (
+	AbuseReportTargetRequest AbuseReportTarget = "request"
+	AbuseReportTargetComment AbuseReportTarget = "comment"
+	AbuseReportTargetUser    AbuseReportTarget = "user"
+)
+
+// IsValid проверяет, что тип объекта жалобы поддерживается
+func (t AbuseReportTarget) IsValid() bool {
+	switch t {
+	case AbuseReportTargetRequest, AbuseReportTargetComment, AbuseReportTargetUser:
+		return true
+	}
+	return false
+}
+
+// IsContent сообщает, можно ли скрыть объект жалобы: заявку или комментарий
+func (t AbuseReportTarget) IsContent() bool {
+	return t == AbuseReportTargetRequest || t == AbuseReportTargetComment
+}
+
+// AbuseReportReason представляет причину жалобы
+type AbuseReportReason string
+
+// Константы для причин жалобы
+const (
+	// AbuseReportReasonFake - поддельная или мошенническая заявка
+	AbuseReportReasonFake  AbuseReportReason = "fake"
+	AbuseReportReasonSpam  AbuseReportReason = "spam"
+	AbuseReportReasonAbuse AbuseReportReason = "abuse"
+	// AbuseReportReasonNoShow - волонтер не пришел на взятую заявку
+	AbuseReportReasonNoShow AbuseReportReason = "no_show"
+	AbuseReportReasonOther  AbuseReportReason = "other"
+)
+
+// IsValid проверяет, что причина жалобы поддерживается
+func (r AbuseReportReason) IsValid() bool {
+	switch r {
+	case AbuseReportReasonFake, AbuseReportReasonSpam, AbuseReportReasonAbuse,
+		AbuseReportReasonNoShow, AbuseReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// AbuseReportStatus представляет состояние жалобы
+type AbuseReportStatus string
+
+// Константы для состояний жалобы
+const (
+	AbuseReportOpen      AbuseReportStatus = "open"
+	AbuseReportDismissed AbuseReportStatus = "dismissed"
+	// AbuseReportActioned - по жалобе скрыт контент или заблокирован пользователь
+	AbuseReportActioned AbuseReportStatus = "actioned"
+)
+
+// AbuseReportAction представляет решение администратора по жалобе
+type AbuseReportAction string
+
+// Константы для решений по жалобе
+const (
+	// AbuseReportActionDismiss отклоняет жалобы; скрытый автоматически контент снова показывается
+	AbuseReportActionDismiss AbuseReportAction = "dismiss"
+	// AbuseReportActionHide скрывает заявку или комментарий
+	AbuseReportActionHide AbuseReportAction = "hide"
+	// AbuseReportActionSuspend блокирует пользователя или автора контента и скрывает контент
+	AbuseReportActionSuspend AbuseReportAction = "suspend"
+)
+
+// AbuseReport представляет жалобу пользователя
+type AbuseReport struct {
+	ID             int                `json:"id" db:"id"`
+	ReporterID     int                `json:"reporterId" db:"reporter_id"`
+	TargetType     AbuseReportTarget  `json:"targetType" db:"target_type"`
+	TargetID       int                `json:"targetId" db:"target_id"`
+	Reason         AbuseReportReason  `json:"reason" db:"reason"`
+	Details        string             `json:"details" db:"details"`
+	Status         AbuseReportStatus  `json:"status" db:"status"`
+	Action         *AbuseReportAction `json:"action" db:"action"`
+	ResolutionNote string             `json:"resolutionNote" db:"resolution_note"`
+	ResolvedBy     *int               `json:"resolvedBy" db:"resolved_by"`
+	ResolvedAt     *time.Time         `json:"resolvedAt" db:"resolved_at"`
+	CreatedAt      time.Time          `json:"createdAt" db:"created_at"`
+
+	// Дополнительные поля очереди жалоб
+	// TargetReports - число открытых жалоб на тот же объект
+	TargetReports int `json:"targetReports,omitempty" db:"target_reports"`
+	// TargetHidden - объект скрыт (контент) или заблокирован (пользователь)
+	TargetHidden bool `json:"targetHidden" db:"target_hidden"`
+}
+
+// AbuseReportInput представляет жалобу на заявку, комментарий или пользователя
+type AbuseReportInput struct {
+	TargetType AbuseReportTarget `json:"targetType" validate:"required,oneof=request comment user"`
+	TargetID   int               `json:"targetId" validate:"required"`
+	Reason     AbuseReportReason `json:"reason" validate:"required,oneof=fake spam abuse no_show other"`
+	// Details обязательны для причины other
+	Details string `json:"details" validate:"max=1000"`
+}
+
+// AbuseReportResolveInput представляет решение администратора по жалобе.
+// Решение применяется ко всем открытым жалобам на тот же объект.
+type AbuseReportResolveInput struct {
+	Action AbuseReportAction `json:"action" validate:"required,oneof=dismiss hide suspend"`
+	Note   string            `json:"note" validate:"max=1000"`
+}
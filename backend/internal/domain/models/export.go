@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// VolunteerActivityFilter представляет период и организацию отчета об активности волонтеров
+type VolunteerActivityFilter struct {
+	// From и To задают полуинтервал [From, To)
+	From time.Time
+	To   time.Time
+	// PartnerID ограничивает отчет заявками партнерской организации; 0 - все заявки
+	PartnerID int
+}
+
+// VolunteerActivity представляет активность волонтера за период:
+// выполненные заявки, засчитанное время и полученные оценки
+type VolunteerActivity struct {
+	VolunteerID       int      `json:"volunteerId" db:"volunteer_id"`
+	Username          string   `json:"username" db:"username"`
+	FirstName         string   `json:"firstName" db:"first_name"`
+	LastName          string   `json:"lastName" db:"last_name"`
+	CompletedRequests int      `json:"completedRequests" db:"completed_requests"`
+	Minutes           int      `json:"minutes" db:"minutes"`
+	RatingsCount      int      `json:"ratingsCount" db:"ratings_count"`
+	AverageRating     *float64 `json:"averageRating" db:"average_rating"`
+}
@@ -87,6 +87,13 @@ const (
 	NotificationTypeRequestCompleted    NotificationType = "request_completed"
 	NotificationTypeRequestAccepted     NotificationType = "request_accepted"
 	NotificationTypeRequestCancelled    NotificationType = "request_cancelled"
+	NotificationTypeRequestExpired      NotificationType = "request_expired"
+	NotificationTypeRequestEscalated    NotificationType = "request_escalated"
+	NotificationTypeRequestReleased     NotificationType = "request_released"
+	NotificationTypeRequestHandover     NotificationType = "request_handover"
+	NotificationTypeRequestAssigned     NotificationType = "request_assigned"
+	NotificationTypeRequestModerated    NotificationType = "request_moderated"
+	NotificationTypeRequestMessage      NotificationType = "request_message"
 )
 
 // Notification представляет модель уведомления для пользователя
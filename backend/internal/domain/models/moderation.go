@@ -0,0 +1,36 @@
+package models
+
+// ModerationPolicy задает правила автоматического одобрения заявок.
+// Заявка публикуется сразу, если проверка выключена, автор - администратор
+// или у автора достаточно успешно выполненных заявок и мало отклоненных.
+type ModerationPolicy struct {
+	Enabled bool
+	// TrustedCompletedRequests - сколько выполненных заявок нужно автору для публикации без проверки
+	TrustedCompletedRequests int
+	// MaxRejectedRequests - сколько отклоненных заявок допускается у автора, публикуемого без проверки
+	MaxRejectedRequests int
+}
+
+// DefaultModerationPolicy - правила проверки заявок по умолчанию
+var DefaultModerationPolicy = ModerationPolicy{
+	Enabled:                  true,
+	TrustedCompletedRequests: 2,
+	MaxRejectedRequests:      0,
+}
+
+// RequesterHistory представляет историю заявок автора для правил автоматического одобрения
+type RequesterHistory struct {
+	RequiresReview    bool `db:"requires_review"`
+	CompletedRequests int  `db:"completed_requests"`
+	RejectedRequests  int  `db:"rejected_requests"`
+}
+
+// ModerationDecisionInput представляет решение администратора по заявке на проверке
+type ModerationDecisionInput struct {
+	Reason string `json:"reason"`
+}
+
+// UserReviewFlagInput отмечает пользователя, все заявки которого проходят проверку
+type UserReviewFlagInput struct {
+	RequiresReview bool `json:"requiresReview"`
+}
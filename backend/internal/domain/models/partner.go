@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// PartnerMemberRole представляет роль сотрудника в партнерской организации
+type PartnerMemberRole string
+
+// Константы для ролей сотрудников партнерской организации
+const (
+	// PartnerMemberRoleManager управляет составом сотрудников и профилем организации
+	PartnerMemberRoleManager PartnerMemberRole = "manager"
+	// PartnerMemberRoleStaff создает заявки от имени организации
+	PartnerMemberRoleStaff PartnerMemberRole = "staff"
+)
+
+// PartnerOrganization представляет партнерскую организацию, например больницу.
+// Заявки от имени организации могут создавать только сотрудники проверенного партнера.
+type PartnerOrganization struct {
+	ID           int       `json:"id" db:"id"`
+	Name         string    `json:"name" db:"name"`
+	Description  string    `json:"description" db:"description"`
+	LogoURL      string    `json:"logoUrl" db:"logo_url"`
+	WebsiteURL   string    `json:"websiteUrl" db:"website_url"`
+	ContactEmail string    `json:"contactEmail" db:"contact_email"`
+	ContactPhone string    `json:"contactPhone" db:"contact_phone"`
+	Address      string    `json:"address" db:"address"`
+	IsVerified   bool      `json:"isVerified" db:"is_verified"`
+	MembersCount int       `json:"membersCount" db:"members_count"`
+	CreatedAt    time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt    time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// PartnerInput представляет данные для создания или изменения партнерской организации
+type PartnerInput struct {
+	Name         string `json:"name" db:"name" validate:"required,max=100"`
+	Description  string `json:"description" db:"description"`
+	LogoURL      string `json:"logoUrl" db:"logo_url"`
+	WebsiteURL   string `json:"websiteUrl" db:"website_url"`
+	ContactEmail string `json:"contactEmail" db:"contact_email" validate:"omitempty,email,max=100"`
+	ContactPhone string `json:"contactPhone" db:"contact_phone" validate:"omitempty,max=20"`
+	Address      string `json:"address" db:"address"`
+}
+
+// PartnerVerificationInput отмечает организацию проверенной или снимает отметку
+type PartnerVerificationInput struct {
+	IsVerified bool `json:"isVerified"`
+}
+
+// PartnerMember представляет сотрудника партнерской организации
+type PartnerMember struct {
+	PartnerID int               `json:"partnerId" db:"partner_id"`
+	UserID    int               `json:"userId" db:"user_id"`
+	Role      PartnerMemberRole `json:"role" db:"role"`
+	Username  string            `json:"username" db:"username"`
+	FirstName string            `json:"firstName" db:"first_name"`
+	LastName  string            `json:"lastName" db:"last_name"`
+	PhotoURL  string            `json:"photoUrl" db:"photo_url"`
+	CreatedAt time.Time         `json:"createdAt" db:"created_at"`
+}
+
+// PartnerMemberInput представляет данные для добавления сотрудника или смены его роли
+type PartnerMemberInput struct {
+	UserID int               `json:"userId" validate:"required"`
+	Role   PartnerMemberRole `json:"role" validate:"omitempty,oneof=manager staff"`
+}
@@ -0,0 +1,69 @@
+package models
+
+// RecommendationWeights задает вес каждого сигнала в оценке заявки для волонтера.
+// Сигналы нормированы к отрезку [0, 1], поэтому веса сравнимы между собой.
+type RecommendationWeights struct {
+	// Category - опыт волонтера в категории заявки
+	Category float64
+	// Distance - близость заявки к дому волонтера
+	Distance float64
+	// Rating - рейтинг волонтера: опытным волонтерам чаще предлагаются срочные заявки
+	Rating float64
+	// Wait - как долго заявка ждет волонтера
+	Wait float64
+	// Priority - приоритет заявки
+	Priority float64
+}
+
+// DefaultRecommendationWeights - веса сигналов по умолчанию
+var DefaultRecommendationWeights = RecommendationWeights{
+	Category: 3,
+	Distance: 3,
+	Rating:   1,
+	Wait:     2,
+	Priority: 2,
+}
+
+// Сигналы оценки рекомендованной заявки
+const (
+	RecommendationFactorCategory = "category"
+	RecommendationFactorDistance = "distance"
+	RecommendationFactorRating   = "rating"
+	RecommendationFactorWait     = "wait"
+	RecommendationFactorPriority = "priority"
+)
+
+// RecommendationFactor объясняет вклад одного сигнала в оценку заявки
+type RecommendationFactor struct {
+	Name        string  `json:"name"`
+	Score       float64 `json:"score"`
+	Description string  `json:"description"`
+}
+
+// RecommendedRequest представляет заявку, предложенную волонтеру, с оценкой и ее главными причинами
+type RecommendedRequest struct {
+	Request RequestFullInfo        `json:"request"`
+	Score   float64                `json:"score"`
+	Factors []RecommendationFactor `json:"factors"`
+}
+
+// RecommendationCandidate представляет открытую заявку, которую можно предложить волонтеру
+type RecommendationCandidate struct {
+	RequestFullInfo
+	CategoryID int `db:"category_id"`
+}
+
+// VolunteerProfile представляет сведения о волонтере, используемые для рекомендаций
+type VolunteerProfile struct {
+	HomeLat *float64 `db:"home_lat"`
+	HomeLon *float64 `db:"home_lon"`
+	Rating  float64  `db:"rating"`
+	// CompletedByCategory - число выполненных волонтером заявок по ID категории
+	CompletedByCategory map[int]int `db:"-"`
+}
+
+// HomeLocationInput представляет домашнюю точку волонтера; пустые координаты удаляют ее
+type HomeLocationInput struct {
+	Lat *float64 `json:"lat" validate:"omitempty,latitude"`
+	Lon *float64 `json:"lon" validate:"omitempty,longitude"`
+}
@@ -13,8 +13,26 @@ const (
 	RequestStatusInProgress RequestStatus = "in_progress"
 	RequestStatusCompleted  RequestStatus = "completed"
 	RequestStatusCancelled  RequestStatus = "cancelled"
+	// RequestStatusExpired - срок заявки истек, а волонтер так и не нашелся
+	RequestStatusExpired RequestStatus = "expired"
+	// RequestStatusPendingReview - заявка нового или отмеченного автора ждет проверки администратором
+	RequestStatusPendingReview RequestStatus = "pending_review"
+	// RequestStatusChangesRequested - администратор вернул заявку автору на доработку
+	RequestStatusChangesRequested RequestStatus = "changes_requested"
+	// RequestStatusRejected - администратор отклонил заявку при проверке
+	RequestStatusRejected RequestStatus = "rejected"
 )
 
+// IsFeedVisible сообщает, видна ли заявка со статусом s в общей ленте.
+// Заявки на проверке и отклоненные видны только их автору.
+func (s RequestStatus) IsFeedVisible() bool {
+	switch s {
+	case RequestStatusPendingReview, RequestStatusChangesRequested, RequestStatusRejected:
+		return false
+	}
+	return true
+}
+
 // RequestPriority представляет приоритет заявки
 type RequestPriority string
 
@@ -25,27 +43,37 @@ const (
 	RequestPriorityHigh   RequestPriority = "high"
 )
 
-// HelpRequest представляет заявку на помощь
+// HelpRequest представляет заявку на помощь.
+// AssignedTo - первый из закрепленных волонтеров, все волонтеры хранятся в request_assignments.
 type HelpRequest struct {
-	ID          int             `json:"id" db:"id"`
-	Title       string          `json:"title" db:"title"`
-	Description string          `json:"description" db:"description"`
-	Status      RequestStatus   `json:"status" db:"status"`
-	CategoryID  int             `json:"categoryId" db:"category_id"`
-	Priority    RequestPriority `json:"priority" db:"priority"`
-	Location    string          `json:"location" db:"location"`
-	RequesterID int             `json:"requesterId" db:"requester_id"`
-	AssignedTo  *int            `json:"assignedTo" db:"assigned_to"`
-	IsDeleted   bool            `json:"isDeleted" db:"is_deleted"`
-	CreatedAt   time.Time       `json:"createdAt" db:"created_at"`
-	UpdatedAt   time.Time       `json:"updatedAt" db:"updated_at"`
-	CompletedAt *time.Time      `json:"completedAt" db:"completed_at"`
+	ID             int             `json:"id" db:"id"`
+	Title          string          `json:"title" db:"title"`
+	Description    string          `json:"description" db:"description"`
+	Status         RequestStatus   `json:"status" db:"status"`
+	CategoryID     int             `json:"categoryId" db:"category_id"`
+	Priority       RequestPriority `json:"priority" db:"priority"`
+	Location       string          `json:"location" db:"location"`
+	LocationLat    *float64        `json:"locationLat" db:"location_lat"`
+	LocationLon    *float64        `json:"locationLon" db:"location_lon"`
+	RequesterID    int             `json:"requesterId" db:"requester_id"`
+	AssignedTo     *int            `json:"assignedTo" db:"assigned_to"`
+	VolunteerSlots int             `json:"volunteerSlots" db:"volunteer_slots"`
+	SeriesID       *int            `json:"seriesId" db:"series_id"`
+	ScheduledFor   *time.Time      `json:"scheduledFor" db:"scheduled_for"`
+	DueAt          *time.Time      `json:"dueAt" db:"due_at"`
+	PartnerID      *int            `json:"partnerId" db:"partner_id"`
+	IsDeleted      bool            `json:"isDeleted" db:"is_deleted"`
+	Hidden         bool            `json:"hidden" db:"hidden"`
+	CreatedAt      time.Time       `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updatedAt" db:"updated_at"`
+	CompletedAt    *time.Time      `json:"completedAt" db:"completed_at"`
 
 	// Дополнительные поля, не хранящиеся в базе данных
 	Requester     *UserShort       `json:"requester,omitempty" db:"-"`
 	Volunteer     *UserShort       `json:"volunteer,omitempty" db:"-"`
 	Category      *RequestCategory `json:"category,omitempty" db:"-"`
 	CommentsCount int              `json:"commentsCount,omitempty" db:"-"`
+	VolunteerIDs  []int            `json:"volunteerIds,omitempty" db:"-"`
 }
 
 // UserShort представляет сокращенную информацию о пользователе для включения в запросы
@@ -79,6 +107,9 @@ type RequestFullInfo struct {
 	CategoryColor string          `json:"categoryColor" db:"category_color"`
 	Priority      RequestPriority `json:"priority" db:"priority"`
 	Location      string          `json:"location" db:"location"`
+	LocationLat   *float64        `json:"locationLat" db:"location_lat"`
+	LocationLon   *float64        `json:"locationLon" db:"location_lon"`
+	DistanceKm    *float64        `json:"distanceKm,omitempty" db:"distance_km"`
 	CreatedAt     time.Time       `json:"createdAt" db:"created_at"`
 	UpdatedAt     time.Time       `json:"updatedAt" db:"updated_at"`
 	CompletedAt   *time.Time      `json:"completedAt" db:"completed_at"`
@@ -95,6 +126,24 @@ type RequestFullInfo struct {
 	VolunteerLastName  *string `json:"volunteerLastName" db:"volunteer_last_name"`
 	VolunteerPhotoURL  *string `json:"volunteerPhotoUrl" db:"volunteer_photo_url"`
 
+	// VolunteerSlots - сколько волонтеров нужно заявке, Volunteers - все закрепленные волонтеры
+	VolunteerSlots int         `json:"volunteerSlots" db:"volunteer_slots"`
+	Volunteers     []UserShort `json:"volunteers" db:"-"`
+
+	// SeriesID и ScheduledFor заполнены у повторений серии повторяющихся заявок
+	SeriesID     *int       `json:"seriesId" db:"series_id"`
+	ScheduledFor *time.Time `json:"scheduledFor" db:"scheduled_for"`
+
+	// DueAt - к какому сроку нужна помощь; после него заявка без волонтера истекает
+	DueAt *time.Time `json:"dueAt" db:"due_at"`
+
+	// PartnerID и PartnerName заполнены у заявок от имени проверенной партнерской организации
+	PartnerID   *int    `json:"partnerId" db:"partner_id"`
+	PartnerName *string `json:"partnerName" db:"partner_name"`
+
+	// Hidden - заявка скрыта по жалобам, ее видят только автор и администраторы
+	Hidden bool `json:"hidden" db:"hidden"`
+
 	CommentsCount int `json:"commentsCount" db:"comments_count"`
 }
 
@@ -107,8 +156,21 @@ type RequestComment struct {
 	CreatedAt time.Time `json:"createdAt" db:"created_at"`
 	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
 
+	// ParentID заполнен у ответа на комментарий; ответы вложены только на один уровень
+	ParentID *int `json:"parentId" db:"parent_id"`
+	// EditedAt - когда автор последний раз изменил текст
+	EditedAt *time.Time `json:"editedAt" db:"edited_at"`
+	// DeletedAt заполнен у удаленного комментария. Удаленный комментарий показывается
+	// без текста и автора, только если у него остались ответы
+	DeletedAt *time.Time `json:"deletedAt" db:"deleted_at"`
+	// DeletedByModerator - комментарий удален администратором, а не автором
+	DeletedByModerator bool `json:"deletedByModerator" db:"deleted_by_moderator"`
+	// Hidden - комментарий скрыт по жалобам и показывается без текста
+	Hidden bool `json:"hidden" db:"hidden"`
+
 	// Дополнительные поля
-	User *UserShort `json:"user,omitempty" db:"-"`
+	User    *UserShort       `json:"user,omitempty" db:"-"`
+	Replies []RequestComment `json:"replies,omitempty" db:"-"`
 }
 
 // RequestRating представляет оценку выполненной заявки
@@ -122,8 +184,40 @@ type RequestRating struct {
 	CreatedAt time.Time `json:"createdAt" db:"created_at"`
 
 	// Дополнительные поля
-	Rater *UserShort `json:"rater,omitempty" db:"-"`
-	Rated *UserShort `json:"rated,omitempty" db:"-"`
+	Rater        *UserShort `json:"rater,omitempty" db:"-"`
+	Rated        *UserShort `json:"rated,omitempty" db:"-"`
+	RequestTitle string     `json:"requestTitle,omitempty" db:"-"`
+}
+
+// RequestStatusHistory представляет запись о переходе заявки между статусами
+type RequestStatusHistory struct {
+	ID         int            `json:"id" db:"id"`
+	RequestID  int            `json:"requestId" db:"request_id"`
+	FromStatus *RequestStatus `json:"fromStatus" db:"from_status"`
+	ToStatus   RequestStatus  `json:"toStatus" db:"to_status"`
+	ActorID    *int           `json:"actorId" db:"actor_id"`
+	Reason     *string        `json:"reason" db:"reason"`
+	CreatedAt  time.Time      `json:"createdAt" db:"created_at"`
+
+	ActorUsername  *string `json:"actorUsername" db:"actor_username"`
+	ActorFirstName *string `json:"actorFirstName" db:"actor_first_name"`
+	ActorLastName  *string `json:"actorLastName" db:"actor_last_name"`
+}
+
+// RequestStatusChange описывает переход заявки в новый статус.
+// Переход выполняется только если заявка все еще находится в статусе From.
+type RequestStatusChange struct {
+	RequestID  int
+	From       RequestStatus
+	To         RequestStatus
+	AssignedTo *int
+	ActorID    *int
+	Reason     string
+}
+
+// RequestStatusChangeInput представляет необязательные данные для смены статуса заявки
+type RequestStatusChangeInput struct {
+	Reason string `json:"reason" validate:"omitempty,max=500"`
 }
 
 // RequestCreateInput представляет данные для создания новой заявки
@@ -133,28 +227,132 @@ type RequestCreateInput struct {
 	CategoryID  int             `json:"categoryId" validate:"required"`
 	Priority    RequestPriority `json:"priority" validate:"omitempty,oneof=low medium high"`
 	Location    string          `json:"location" validate:"required"`
+	LocationLat *float64        `json:"locationLat" validate:"omitempty,latitude"`
+	LocationLon *float64        `json:"locationLon" validate:"omitempty,longitude"`
+	// VolunteerSlots - сколько волонтеров нужно заявке, по умолчанию один
+	VolunteerSlots int `json:"volunteerSlots" validate:"omitempty,min=1,max=10"`
+	// DueAt - необязательный срок, к которому нужна помощь
+	DueAt *time.Time `json:"dueAt"`
+	// Recurrence делает заявку повторяющейся: создается серия и ее первое повторение
+	Recurrence *RecurrenceRuleInput `json:"recurrence"`
+	// PartnerID - организация, от имени которой сотрудник создает заявку
+	PartnerID *int `json:"partnerId"`
 }
 
-// RequestUpdateInput представляет данные для обновления заявки
+// RequestUpdateInput представляет данные для обновления заявки.
+// Смена статуса проверяется машиной состояний заявки так же, как отдельные действия;
+// "new" и "in_progress" выставляются только взятием и освобождением заявки.
 type RequestUpdateInput struct {
 	Title       *string          `json:"title" validate:"omitempty,min=5,max=255"`
 	Description *string          `json:"description" validate:"omitempty,min=10"`
-	Status      *RequestStatus   `json:"status" validate:"omitempty,oneof=new in_progress completed cancelled"`
+	Status      *RequestStatus   `json:"status" validate:"omitempty,oneof=completed cancelled"`
 	CategoryID  *int             `json:"categoryId"`
 	Priority    *RequestPriority `json:"priority" validate:"omitempty,oneof=low medium high"`
 	Location    *string          `json:"location"`
+	LocationLat *float64         `json:"locationLat" validate:"omitempty,latitude"`
+	LocationLon *float64         `json:"locationLon" validate:"omitempty,longitude"`
 	AssignedTo  *int             `json:"assignedTo"`
+	// VolunteerSlots можно менять, пока заявка не набрала волонтеров
+	VolunteerSlots *int `json:"volunteerSlots" validate:"omitempty,min=1,max=10"`
+	// DueAt можно менять, пока заявка не взята в работу
+	DueAt *time.Time `json:"dueAt"`
+}
+
+// RequestSort определяет порядок ленты заявок
+type RequestSort string
+
+// Порядки ленты заявок
+const (
+	// RequestSortDefault - статус, приоритет, новизна
+	RequestSortDefault RequestSort = ""
+	// RequestSortDueSoon - сначала заявки с ближайшим сроком, заявки без срока в конце
+	RequestSortDueSoon RequestSort = "due_soon"
+)
+
+// RequestFilter представляет параметры фильтрации и пагинации списка заявок
+type RequestFilter struct {
+	Status   string
+	Category string
+	Priority string
+	Sort     RequestSort
+	// RequesterID и VolunteerID ограничивают список заявками автора или волонтера; 0 - без ограничения
+	RequesterID int
+	VolunteerID int
+	// PartnerID ограничивает список заявками проверенной партнерской организации; 0 - без ограничения
+	PartnerID int
+	// CreatedFrom и CreatedTo ограничивают список заявками, созданными в полуинтервале [CreatedFrom, CreatedTo)
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// UserID - текущий пользователь, если он авторизован; 0 для анонимного запроса
+	UserID int
+	// Cursor - курсор следующей страницы из RequestList.NextCursor. Если он задан, Offset не используется
+	Cursor string
+	Limit  int
+	Offset int
+}
+
+// RequestList представляет страницу ленты заявок
+type RequestList struct {
+	Items []RequestFullInfo
+	// Total - количество заявок, подходящих под фильтр, без учета пагинации
+	Total int
+	// NextCursor - курсор следующей страницы; пустой, если страница последняя
+	NextCursor string
+}
+
+// NearbyRequestFilter представляет параметры поиска заявок рядом с точкой
+type NearbyRequestFilter struct {
+	RequestFilter
+	Lat      float64
+	Lon      float64
+	RadiusKm float64
+}
+
+// RequestSearchFilter представляет параметры полнотекстового поиска заявок
+type RequestSearchFilter struct {
+	RequestFilter
+	Query string
+}
+
+// RequestSearchResult представляет заявку, найденную полнотекстовым поиском.
+// Фрагменты экранированы как HTML, найденные слова в них выделены тегами <mark>.
+type RequestSearchResult struct {
+	RequestFullInfo
+	Rank           float64 `json:"rank" db:"rank"`
+	TitleHighlight string  `json:"titleHighlight" db:"title_highlight"`
+	Snippet        string  `json:"snippet" db:"snippet"`
 }
 
 // RequestCommentInput представляет данные для создания комментария
 type RequestCommentInput struct {
 	Text string `json:"text" validate:"required,min=1,max=1000"`
+	// ParentID - комментарий, на который дается ответ. Ответ на ответ попадает в ту же ветку
+	ParentID *int `json:"parentId"`
+}
+
+// RequestCommentUpdateInput представляет новый текст комментария
+type RequestCommentUpdateInput struct {
+	Text string `json:"text" validate:"required,min=1,max=1000"`
 }
 
 // RequestRatingInput представляет данные для создания оценки
 type RequestRatingInput struct {
 	Rating  int    `json:"rating" validate:"required,min=1,max=5"`
 	Comment string `json:"comment" validate:"omitempty,max=500"`
+	// RatedID - оцениваемый волонтер; автор заявки с несколькими волонтерами указывает его обязательно.
+	// Волонтер всегда оценивает автора заявки.
+	RatedID *int `json:"ratedId"`
+}
+
+// UserReviews представляет рейтинг пользователя и страницу полученных им отзывов
+type UserReviews struct {
+	UserID int `json:"userId"`
+	// Rating - байесовское среднее оценок с учетом их числа
+	Rating        float64         `json:"rating"`
+	RatingsCount  int             `json:"ratingsCount"`
+	AverageRating float64         `json:"averageRating"`
+	Reviews       []RequestRating `json:"reviews"`
+	Total         int             `json:"total"`
 }
 
 // RequestStatus представляет статистику по заявкам
@@ -167,4 +365,5 @@ type RequestStats struct {
 	Partners            int     `json:"partners" db:"total_partners"`
 	TotalVolunteerHours int     `json:"totalVolunteerHours" db:"total_volunteer_hours"`
 	AverageRating       float64 `json:"averageRating" db:"average_rating"`
+	ExpiredRequests     int     `json:"expiredRequests" db:"expired_requests"`
 }
@@ -0,0 +1,34 @@
+package models
+
+// RequestAssignInput представляет назначение волонтера на заявку координатором
+type RequestAssignInput struct {
+	VolunteerID int `json:"volunteerId" validate:"required"`
+}
+
+// RequestReassignInput представляет передачу заявки координатором от одного волонтера другому
+type RequestReassignInput struct {
+	FromVolunteerID int    `json:"fromVolunteerId" validate:"required"`
+	ToVolunteerID   int    `json:"toVolunteerId" validate:"required"`
+	Reason          string `json:"reason"`
+}
+
+// RequestBulkAssignInput представляет назначение одного волонтера сразу на несколько заявок
+type RequestBulkAssignInput struct {
+	RequestIDs  []int `json:"requestIds" validate:"required,min=1,max=50"`
+	VolunteerID int   `json:"volunteerId" validate:"required"`
+}
+
+// RequestBulkAssignResult представляет результат назначения на одну заявку из пакета.
+// Заявки назначаются независимо: ошибка по одной заявке не отменяет остальные.
+type RequestBulkAssignResult struct {
+	RequestID int              `json:"requestId"`
+	Request   *RequestFullInfo `json:"request,omitempty"`
+	Error     string           `json:"error,omitempty"`
+}
+
+// VolunteerLoad представляет текущую загрузку волонтера для диспетчеризации заявок
+type VolunteerLoad struct {
+	UserShort
+	// ActiveRequests - число незавершенных заявок, за которыми закреплен волонтер
+	ActiveRequests int `json:"activeRequests" db:"active_requests"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// EscalationRule представляет порог эскалации заявок одного приоритета.
+// Правило с категорией важнее общего правила без категории.
+type EscalationRule struct {
+	ID         int             `json:"id" db:"id"`
+	CategoryID *int            `json:"categoryId" db:"category_id"`
+	Priority   RequestPriority `json:"priority" db:"priority"`
+	// ThresholdMinutes - сколько заявка может ждать волонтера до эскалации
+	ThresholdMinutes int `json:"thresholdMinutes" db:"threshold_minutes"`
+	// RaisePriority - повышать ли приоритет заявки на одну ступень при эскалации
+	RaisePriority bool      `json:"raisePriority" db:"raise_priority"`
+	CreatedAt     time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt     time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// EscalationRuleInput представляет данные для создания или замены правила эскалации
+type EscalationRuleInput struct {
+	CategoryID       *int            `json:"categoryId"`
+	Priority         RequestPriority `json:"priority" validate:"required,oneof=low medium high"`
+	ThresholdMinutes int             `json:"thresholdMinutes" validate:"required,min=5"`
+	RaisePriority    bool            `json:"raisePriority"`
+}
+
+// RequestEscalation представляет запись журнала эскалаций заявки
+type RequestEscalation struct {
+	ID               int             `json:"id" db:"id"`
+	RequestID        int             `json:"requestId" db:"request_id"`
+	RuleID           *int            `json:"ruleId" db:"rule_id"`
+	Level            int             `json:"level" db:"level"`
+	FromPriority     RequestPriority `json:"fromPriority" db:"from_priority"`
+	ToPriority       RequestPriority `json:"toPriority" db:"to_priority"`
+	ThresholdMinutes int             `json:"thresholdMinutes" db:"threshold_minutes"`
+	WaitedMinutes    int             `json:"waitedMinutes" db:"waited_minutes"`
+	NotifiedCount    int             `json:"notifiedCount" db:"notified_count"`
+	CreatedAt        time.Time       `json:"createdAt" db:"created_at"`
+}
+
+// EscalationCandidate представляет заявку без волонтера, ожидание которой превысило порог правила
+type EscalationCandidate struct {
+	RequestID        int             `db:"request_id"`
+	Title            string          `db:"title"`
+	RequesterID      int             `db:"requester_id"`
+	CategoryID       int             `db:"category_id"`
+	Priority         RequestPriority `db:"priority"`
+	CreatedAt        time.Time       `db:"created_at"`
+	Level            int             `db:"level"`
+	RuleID           int             `db:"rule_id"`
+	ThresholdMinutes int             `db:"threshold_minutes"`
+	RaisePriority    bool            `db:"raise_priority"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// RequestStatusEvent представляет событие потока о смене статуса заявки
+type RequestStatusEvent struct {
+	RequestID int           `json:"requestId"`
+	Status    RequestStatus `json:"status"`
+	// PreviousStatus пуст, если прежний статус неизвестен
+	PreviousStatus RequestStatus `json:"previousStatus,omitempty"`
+	AssignedTo     *int          `json:"assignedTo"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// RequestHandoverStatus представляет состояние записи об отказе волонтера от заявки
+type RequestHandoverStatus string
+
+// Константы для состояний отказа и передачи заявки
+const (
+	// RequestHandoverReleased - волонтер освободил место, заявка вернулась в поиск
+	RequestHandoverReleased RequestHandoverStatus = "released"
+	// RequestHandoverPending - передача ждет ответа волонтера, которому передается заявка
+	RequestHandoverPending   RequestHandoverStatus = "pending"
+	RequestHandoverAccepted  RequestHandoverStatus = "accepted"
+	RequestHandoverDeclined  RequestHandoverStatus = "declined"
+	RequestHandoverCancelled RequestHandoverStatus = "cancelled"
+)
+
+// RequestHandover представляет отказ волонтера от заявки или передачу ее другому волонтеру
+type RequestHandover struct {
+	ID              int                   `json:"id" db:"id"`
+	RequestID       int                   `json:"requestId" db:"request_id"`
+	FromVolunteerID int                   `json:"fromVolunteerId" db:"from_volunteer_id"`
+	ToVolunteerID   *int                  `json:"toVolunteerId" db:"to_volunteer_id"`
+	Reason          string                `json:"reason" db:"reason"`
+	Status          RequestHandoverStatus `json:"status" db:"status"`
+	CreatedAt       time.Time             `json:"createdAt" db:"created_at"`
+	ResolvedAt      *time.Time            `json:"resolvedAt" db:"resolved_at"`
+}
+
+// RequestReleaseInput представляет отказ волонтера от взятой заявки.
+// Если указан HandoverTo, заявка передается этому волонтеру после его согласия.
+type RequestReleaseInput struct {
+	Reason     string `json:"reason" validate:"required,max=500"`
+	HandoverTo *int   `json:"handoverTo"`
+}
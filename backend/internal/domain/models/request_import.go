@@ -0,0 +1,68 @@
+package models
+
+// RequestImportRowStatus представляет результат импорта строки CSV
+type RequestImportRowStatus string
+
+// Константы для результатов импорта строки
+const (
+	// RequestImportRowValid - строка прошла проверку, но заявка еще не создана (проверочный прогон или ошибки в других строках)
+	RequestImportRowValid RequestImportRowStatus = "valid"
+	// RequestImportRowInvalid - строка содержит ошибки
+	RequestImportRowInvalid RequestImportRowStatus = "invalid"
+	// RequestImportRowCreated - заявка создана
+	RequestImportRowCreated RequestImportRowStatus = "created"
+	// RequestImportRowSkipped - заявка с этим внешним ID уже импортирована
+	RequestImportRowSkipped RequestImportRowStatus = "skipped"
+)
+
+// RequestImportRow представляет строку CSV, разобранную в данные заявки
+type RequestImportRow struct {
+	// Line - номер строки в файле, считая заголовок первой строкой
+	Line       int
+	ExternalID string
+	Category   string
+	// RequesterPhone и RequesterTelegramID определяют автора заявки; достаточно одного из них
+	RequesterPhone      string
+	RequesterTelegramID string
+	Input               RequestCreateInput
+	Errors              []string
+}
+
+// RequestImportRequester представляет пользователя, найденного по телефону или Telegram ID
+type RequestImportRequester struct {
+	ID         int      `db:"id"`
+	Phone      *string  `db:"phone"`
+	TelegramID *string  `db:"telegram_id"`
+	Role       UserRole `db:"role"`
+	// Suspended - пользователь заблокирован по жалобам и не может создавать заявки
+	Suspended bool `db:"suspended"`
+}
+
+// RequestImportItem представляет проверенную строку, готовую к созданию заявки
+type RequestImportItem struct {
+	Line       int
+	ExternalID string
+	Request    HelpRequest
+}
+
+// RequestImportRowResult представляет результат импорта одной строки
+type RequestImportRowResult struct {
+	Line       int                    `json:"line"`
+	ExternalID string                 `json:"externalId"`
+	Status     RequestImportRowStatus `json:"status"`
+	RequestID  *int                   `json:"requestId,omitempty"`
+	// RequestStatus - статус, в котором заявка создается: new или pending_review, если автору нужна проверка
+	RequestStatus RequestStatus `json:"requestStatus,omitempty"`
+	Errors        []string      `json:"errors,omitempty"`
+}
+
+// RequestImportReport представляет отчет об импорте заявок.
+// Если хотя бы одна строка содержит ошибки, ни одна заявка не создается.
+type RequestImportReport struct {
+	DryRun  bool                     `json:"dryRun"`
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Skipped int                      `json:"skipped"`
+	Invalid int                      `json:"invalid"`
+	Rows    []RequestImportRowResult `json:"rows"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+)
+
+// RequestMessage представляет личное сообщение в переписке по заявке
+type RequestMessage struct {
+	ID        int       `json:"id" db:"id"`
+	RequestID int       `json:"requestId" db:"request_id"`
+	SenderID  int       `json:"senderId" db:"sender_id"`
+	Text      string    `json:"text" db:"text"`
+	CreatedAt time.Time `json:"createdAt" db:"created_at"`
+
+	Sender *UserShort `json:"sender,omitempty" db:"-"`
+}
+
+// RequestMessageInput представляет данные нового сообщения
+type RequestMessageInput struct {
+	Text string `json:"text" validate:"required,min=1,max=2000"`
+}
+
+// RequestMessageRead представляет отметку прочтения: до какого сообщения участник прочитал переписку
+type RequestMessageRead struct {
+	UserID            int       `json:"userId" db:"user_id"`
+	LastReadMessageID int       `json:"lastReadMessageId" db:"last_read_message_id"`
+	ReadAt            time.Time `json:"readAt" db:"read_at"`
+}
+
+// RequestMessageReadInput представляет отметку прочтения от клиента
+type RequestMessageReadInput struct {
+	// MessageID - последнее прочитанное сообщение; 0 - вся переписка
+	MessageID int `json:"messageId"`
+}
+
+// RequestMessageThread представляет страницу переписки по заявке
+type RequestMessageThread struct {
+	RequestID int `json:"requestId"`
+	// Messages - сообщения страницы от старых к новым
+	Messages []RequestMessage     `json:"messages"`
+	Reads    []RequestMessageRead `json:"reads"`
+	// UnreadCount - непрочитанные текущим пользователем сообщения других участников
+	UnreadCount int `json:"unreadCount"`
+	// ReadOnly - заявка завершена или отменена, новые сообщения не принимаются
+	ReadOnly bool `json:"readOnly"`
+	// NextBeforeID - значение before_id для загрузки более ранних сообщений; 0, если их нет
+	NextBeforeID int `json:"nextBeforeId"`
+}
+
+// RequestUnreadMessages представляет непрочитанные сообщения пользователя по одной заявке
+type RequestUnreadMessages struct {
+	RequestID    int    `json:"requestId" db:"request_id"`
+	RequestTitle string `json:"requestTitle" db:"request_title"`
+	UnreadCount  int    `json:"unreadCount" db:"unread_count"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+// RequestReportGroupBy представляет разрез операционного отчета по заявкам
+type RequestReportGroupBy string
+
+// Разрезы операционного отчета
+const (
+	RequestReportByCategory RequestReportGroupBy = "category"
+	RequestReportByPriority RequestReportGroupBy = "priority"
+	RequestReportByWeek     RequestReportGroupBy = "week"
+	RequestReportByMonth    RequestReportGroupBy = "month"
+)
+
+// IsValid проверяет, поддерживается ли разрез отчета
+func (g RequestReportGroupBy) IsValid() bool {
+	switch g {
+	case RequestReportByCategory, RequestReportByPriority, RequestReportByWeek, RequestReportByMonth:
+		return true
+	}
+	return false
+}
+
+// RequestReportFilter представляет параметры операционного отчета.
+// В отчет попадают заявки, созданные в полуинтервале [From, To).
+type RequestReportFilter struct {
+	From    time.Time
+	To      time.Time
+	GroupBy RequestReportGroupBy
+}
+
+// RequestReportRow представляет показатели заявок одной группы отчета.
+// Время назначения считается от создания заявки до первого перехода в "in_progress",
+// время выполнения - от этого перехода до перехода в "completed". Длительности в минутах;
+// nil, если в группе нет ни одной такой заявки.
+type RequestReportRow struct {
+	// Group - категория, приоритет, начало недели (YYYY-MM-DD) или месяц (YYYY-MM)
+	Group     string `json:"group" db:"group_key"`
+	Total     int    `json:"total" db:"total"`
+	Assigned  int    `json:"assigned" db:"assigned"`
+	Completed int    `json:"completed" db:"completed"`
+	Cancelled int    `json:"cancelled" db:"cancelled"`
+	Expired   int    `json:"expired" db:"expired"`
+
+	MedianAssignMinutes   *float64 `json:"medianAssignMinutes" db:"median_assign_minutes"`
+	P90AssignMinutes      *float64 `json:"p90AssignMinutes" db:"p90_assign_minutes"`
+	MedianCompleteMinutes *float64 `json:"medianCompleteMinutes" db:"median_complete_minutes"`
+	P90CompleteMinutes    *float64 `json:"p90CompleteMinutes" db:"p90_complete_minutes"`
+
+	// CancellationRate и ExpiryRate - доли отмененных и истекших заявок от Total
+	CancellationRate float64 `json:"cancellationRate" db:"-"`
+	ExpiryRate       float64 `json:"expiryRate" db:"-"`
+}
+
+// RequestReport представляет операционный отчет по заявкам за период
+type RequestReport struct {
+	From    time.Time            `json:"from"`
+	To      time.Time            `json:"to"`
+	GroupBy RequestReportGroupBy `json:"groupBy"`
+	Groups  []RequestReportRow   `json:"groups"`
+	// Summary - показатели по всем заявкам периода
+	Summary RequestReportRow `json:"summary"`
+}
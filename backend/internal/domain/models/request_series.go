@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+// RecurrenceFrequency представляет периодичность повторяющейся заявки
+type RecurrenceFrequency string
+
+// Константы для периодичности повторяющихся заявок
+const (
+	RecurrenceWeekly   RecurrenceFrequency = "weekly"
+	RecurrenceBiweekly RecurrenceFrequency = "biweekly"
+	RecurrenceMonthly  RecurrenceFrequency = "monthly"
+)
+
+// RequestSeriesStatus представляет статус серии повторяющихся заявок
+type RequestSeriesStatus string
+
+// Константы для статусов серии
+const (
+	RequestSeriesStatusActive RequestSeriesStatus = "active"
+	RequestSeriesStatusPaused RequestSeriesStatus = "paused"
+	RequestSeriesStatusEnded  RequestSeriesStatus = "ended"
+)
+
+// DefaultSeriesTimezone - часовой пояс расписания серии, если он не указан
+const DefaultSeriesTimezone = "Europe/Moscow"
+
+// RequestSeries представляет серию повторяющихся заявок.
+// Каждое повторение создается планировщиком как отдельная HelpRequest
+// заранее, к моменту NextOccurrenceAt.
+type RequestSeries struct {
+	ID             int             `json:"id" db:"id"`
+	RequesterID    int             `json:"requesterId" db:"requester_id"`
+	Title          string          `json:"title" db:"title"`
+	Description    string          `json:"description" db:"description"`
+	CategoryID     int             `json:"categoryId" db:"category_id"`
+	Priority       RequestPriority `json:"priority" db:"priority"`
+	Location       string          `json:"location" db:"location"`
+	LocationLat    *float64        `json:"locationLat" db:"location_lat"`
+	LocationLon    *float64        `json:"locationLon" db:"location_lon"`
+	VolunteerSlots int             `json:"volunteerSlots" db:"volunteer_slots"`
+
+	// Правило повторения: DayOfWeek (0 - воскресенье) для weekly и biweekly,
+	// DayOfMonth для monthly; TimeOfDay в формате ЧЧ:ММ в часовом поясе Timezone
+	Frequency  RecurrenceFrequency `json:"frequency" db:"frequency"`
+	DayOfWeek  *int                `json:"dayOfWeek" db:"day_of_week"`
+	DayOfMonth *int                `json:"dayOfMonth" db:"day_of_month"`
+	TimeOfDay  string              `json:"timeOfDay" db:"time_of_day"`
+	Timezone   string              `json:"timezone" db:"timezone"`
+	StartsOn   time.Time           `json:"startsOn" db:"starts_on"`
+	EndsOn     *time.Time          `json:"endsOn" db:"ends_on"`
+
+	// DefaultVolunteerID - волонтер, который закрепляется за каждым повторением
+	DefaultVolunteerID *int                `json:"defaultVolunteerId" db:"default_volunteer_id"`
+	Status             RequestSeriesStatus `json:"status" db:"status"`
+	NextOccurrenceAt   *time.Time          `json:"nextOccurrenceAt" db:"next_occurrence_at"`
+	CreatedAt          time.Time           `json:"createdAt" db:"created_at"`
+	UpdatedAt          time.Time           `json:"updatedAt" db:"updated_at"`
+}
+
+// RecurrenceRuleInput представляет правило повторения заявки
+type RecurrenceRuleInput struct {
+	Frequency  RecurrenceFrequency `json:"frequency" validate:"required,oneof=weekly biweekly monthly"`
+	DayOfWeek  *int                `json:"dayOfWeek" validate:"omitempty,min=0,max=6"`
+	DayOfMonth *int                `json:"dayOfMonth" validate:"omitempty,min=1,max=28"`
+	Time       string              `json:"time" validate:"required"`
+	Timezone   string              `json:"timezone"`
+	// StartsOn и EndsOn в формате ГГГГ-ММ-ДД; по умолчанию серия начинается сегодня и не заканчивается
+	StartsOn string `json:"startsOn"`
+	EndsOn   string `json:"endsOn"`
+}
+
+// RequestSeriesUpdateInput представляет данные для изменения серии.
+// Изменения применяются к повторениям, которые еще не созданы.
+type RequestSeriesUpdateInput struct {
+	Title          *string              `json:"title" validate:"omitempty,min=5,max=255"`
+	Description    *string              `json:"description" validate:"omitempty,min=10"`
+	CategoryID     *int                 `json:"categoryId"`
+	Priority       *RequestPriority     `json:"priority" validate:"omitempty,oneof=low medium high"`
+	Location       *string              `json:"location"`
+	LocationLat    *float64             `json:"locationLat" validate:"omitempty,latitude"`
+	LocationLon    *float64             `json:"locationLon" validate:"omitempty,longitude"`
+	VolunteerSlots *int                 `json:"volunteerSlots" validate:"omitempty,min=1,max=10"`
+	Recurrence     *RecurrenceRuleInput `json:"recurrence"`
+}
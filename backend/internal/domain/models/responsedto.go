@@ -12,6 +12,7 @@ type PaginatedResponse struct {
 	TotalPages int         `json:"total_pages"`
 	Page       int         `json:"page"`
 	PageSize   int         `json:"page_size"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 // APIErrorResponse представляет стандартизированный ответ с ошибкой
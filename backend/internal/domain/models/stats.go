@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// StatsMetric представляет показатель временного ряда статистики
+type StatsMetric string
+
+// Показатели временных рядов статистики
+const (
+	StatsMetricCreatedRequests   StatsMetric = "created_requests"
+	StatsMetricCompletedRequests StatsMetric = "completed_requests"
+	// StatsMetricActiveVolunteers - различные волонтеры, которые брали заявки, отмечали работу или выполняли заявки
+	StatsMetricActiveVolunteers StatsMetric = "active_volunteers"
+	// StatsMetricNewUsers - все зарегистрированные пользователи, включая волонтеров
+	StatsMetricNewUsers StatsMetric = "new_users"
+)
+
+// IsValid проверяет, поддерживается ли показатель
+func (m StatsMetric) IsValid() bool {
+	switch m {
+	case StatsMetricCreatedRequests, StatsMetricCompletedRequests, StatsMetricActiveVolunteers, StatsMetricNewUsers:
+		return true
+	}
+	return false
+}
+
+// StatsInterval представляет шаг временного ряда
+type StatsInterval string
+
+// Шаги временных рядов
+const (
+	StatsIntervalDay   StatsInterval = "day"
+	StatsIntervalWeek  StatsInterval = "week"
+	StatsIntervalMonth StatsInterval = "month"
+)
+
+// IsValid проверяет, поддерживается ли шаг
+func (i StatsInterval) IsValid() bool {
+	switch i {
+	case StatsIntervalDay, StatsIntervalWeek, StatsIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// StatsTimeseriesFilter представляет параметры временного ряда.
+// Ряд строится по дням полуинтервала [From, To).
+type StatsTimeseriesFilter struct {
+	Metric   StatsMetric
+	Interval StatsInterval
+	From     time.Time
+	To       time.Time
+}
+
+// StatsPoint представляет значение показателя за один шаг ряда
+type StatsPoint struct {
+	// Period - первый день шага (YYYY-MM-DD)
+	Period string `json:"period" db:"period"`
+	Value  int    `json:"value" db:"value"`
+}
+
+// StatsTimeseries представляет временной ряд показателя
+type StatsTimeseries struct {
+	Metric   StatsMetric   `json:"metric"`
+	Interval StatsInterval `json:"interval"`
+	Points   []StatsPoint  `json:"points"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// TimeEntry представляет отметку времени работы волонтера над заявкой.
+// Minutes заполняется при окончании работы и не превышает ограничения на одну отметку.
+type TimeEntry struct {
+	ID               int        `json:"id" db:"id"`
+	RequestID        int        `json:"requestId" db:"request_id"`
+	VolunteerID      int        `json:"volunteerId" db:"volunteer_id"`
+	CheckedInAt      time.Time  `json:"checkedInAt" db:"checked_in_at"`
+	CheckedOutAt     *time.Time `json:"checkedOutAt" db:"checked_out_at"`
+	Minutes          *int       `json:"minutes" db:"minutes"`
+	CorrectedBy      *int       `json:"correctedBy" db:"corrected_by"`
+	CorrectionReason *string    `json:"correctionReason" db:"correction_reason"`
+	CreatedAt        time.Time  `json:"createdAt" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updatedAt" db:"updated_at"`
+}
+
+// TimeEntryCorrectionInput представляет исправление отметки времени администратором
+type TimeEntryCorrectionInput struct {
+	CheckedInAt  time.Time `json:"checkedInAt" validate:"required"`
+	CheckedOutAt time.Time `json:"checkedOutAt" validate:"required"`
+	Reason       string    `json:"reason" validate:"required,max=500"`
+}
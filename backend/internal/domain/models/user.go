@@ -42,6 +42,7 @@ type UserStats struct {
 	CreatedRequests   int       `json:"createdRequests" db:"created_requests"`
 	VolunteerHours    int       `json:"volunteerHours" db:"volunteer_hours"`
 	Rating            float64   `json:"rating" db:"rating"`
+	RatingsCount      int       `json:"ratingsCount" db:"ratings_count"`
 	CreatedAt         time.Time `json:"createdAt" db:"created_at"`
 	UpdatedAt         time.Time `json:"updatedAt" db:"updated_at"`
 }
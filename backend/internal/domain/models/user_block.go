@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// UserBlock представляет запись черного списка: пользователь BlockerID заблокировал пользователя BlockedID
+type UserBlock struct {
+	BlockerID int        `json:"blockerId" db:"blocker_id"`
+	BlockedID int        `json:"blockedId" db:"blocked_id"`
+	Reason    string     `json:"reason" db:"reason"`
+	CreatedAt time.Time  `json:"createdAt" db:"created_at"`
+	Blocker   *UserShort `json:"blocker,omitempty" db:"-"`
+	Blocked   *UserShort `json:"blocked,omitempty" db:"-"`
+}
+
+// UserBlockInput представляет данные для добавления пользователя в черный список
+type UserBlockInput struct {
+	Reason string `json:"reason"`
+}
+
+// UserBlockRelations представляет черный список пользователя и тех, кто внес его в свой черный список
+type UserBlockRelations struct {
+	UserID    int         `json:"userId"`
+	Blocking  []UserBlock `json:"blocking"`
+	BlockedBy []UserBlock `json:"blockedBy"`
+}
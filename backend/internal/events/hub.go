@@ -0,0 +1,214 @@
+// Package events раздает события сервера (уведомления, смены статусов заявок, новые заявки)
+// подписчикам потока событий внутри процесса и хранит недавние события для возобновления потока.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Типы событий
+const (
+	TypeNotification   = "notification"
+	TypeRequestStatus  = "request_status"
+	TypeRequestCreated = "request_created"
+)
+
+// ErrClosed возвращается при подписке на остановленный Hub
+var ErrClosed = errors.New("event hub is closed")
+
+// subscriberBuffer - сколько событий может ждать отправки одному подписчику.
+// Подписчик, который не успевает их читать, отключается и должен переподключиться.
+const subscriberBuffer = 64
+
+// Event представляет событие потока
+type Event struct {
+	// ID - идентификатор для Last-Event-ID; назначается Hub при публикации
+	ID   string      `json:"id"`
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+
+	// UserID - получатель события; 0 - все подписчики
+	UserID int `json:"-"`
+	// Category и Priority заявки используются фильтром подписки на новые заявки
+	Category string `json:"-"`
+	Priority string `json:"-"`
+
+	seq uint64
+}
+
+// Filter ограничивает события подписки
+type Filter struct {
+	// UserID - пользователь потока: получает общие события и адресованные ему
+	UserID int
+	// Category и Priority, если заданы, ограничивают события о новых заявках
+	Category string
+	Priority string
+}
+
+// matches проверяет, нужно ли отправить событие подписчику
+func (f Filter) matches(e *Event) bool {
+	if e.UserID != 0 && e.UserID != f.UserID {
+		return false
+	}
+	if e.Type == TypeRequestCreated {
+		if f.Category != "" && f.Category != e.Category {
+			return false
+		}
+		if f.Priority != "" && f.Priority != e.Priority {
+			return false
+		}
+	}
+	return true
+}
+
+// Hub раздает опубликованные события подписчикам и хранит последние historySize событий.
+// ID события содержит эпоху Hub, поэтому ID от прошлого запуска сервера не принимается за свой.
+type Hub struct {
+	mu      sync.Mutex
+	epoch   string
+	seq     uint64
+	history []Event
+	size    int
+	subs    map[*Subscription]struct{}
+	closed  bool
+}
+
+// NewHub создает Hub, хранящий historySize последних событий для возобновления потока
+func NewHub(historySize int) *Hub {
+	return &Hub{
+		epoch: strconv.FormatInt(time.Now().UnixNano(), 36),
+		size:  historySize,
+		subs:  make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish назначает событию ID, сохраняет его и отправляет подходящим подписчикам.
+// Publish не блокируется: подписчик с переполненным буфером отключается.
+func (h *Hub) Publish(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.seq++
+	e.seq = h.seq
+	e.ID = fmt.Sprintf("%s-%d", h.epoch, h.seq)
+
+	if h.size > 0 {
+		if len(h.history) == h.size {
+			copy(h.history, h.history[1:])
+			h.history = h.history[:h.size-1]
+		}
+		h.history = append(h.history, e)
+	}
+
+	for sub := range h.subs {
+		if !sub.filter.matches(&e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			h.removeLocked(sub)
+		}
+	}
+}
+
+// Subscribe подписывает на события. Если задан lastEventID, возвращаются пропущенные
+// после него события; resumed = false, если их уже нет в истории (или ID от другого
+// запуска сервера) и клиенту нужно заново загрузить данные.
+func (h *Hub) Subscribe(filter Filter, lastEventID string) (sub *Subscription, missed []Event, resumed bool, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, false, ErrClosed
+	}
+
+	resumed = true
+	if lastEventID != "" {
+		missed, resumed = h.missedLocked(filter, lastEventID)
+	}
+
+	sub = &Subscription{
+		hub:    h,
+		filter: filter,
+		ch:     make(chan Event, subscriberBuffer),
+	}
+	sub.C = sub.ch
+	h.subs[sub] = struct{}{}
+
+	return sub, missed, resumed, nil
+}
+
+// missedLocked возвращает события истории после lastEventID, подходящие фильтру
+func (h *Hub) missedLocked(filter Filter, lastEventID string) ([]Event, bool) {
+	epoch, seqStr, ok := strings.Cut(lastEventID, "-")
+	if !ok || epoch != h.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseUint(seqStr, 10, 64)
+	if err != nil || seq > h.seq {
+		return nil, false
+	}
+
+	// Событие сразу после lastEventID должно еще быть в истории
+	if seq < h.seq && (len(h.history) == 0 || h.history[0].seq > seq+1) {
+		return nil, false
+	}
+
+	var missed []Event
+	for i := range h.history {
+		if h.history[i].seq > seq && filter.matches(&h.history[i]) {
+			missed = append(missed, h.history[i])
+		}
+	}
+	return missed, true
+}
+
+// Close отключает всех подписчиков и перестает принимать события и подписки.
+// Вызывается при остановке сервера, чтобы открытые потоки завершились.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for sub := range h.subs {
+		h.removeLocked(sub)
+	}
+}
+
+// removeLocked отключает подписчика, закрывая его канал
+func (h *Hub) removeLocked(sub *Subscription) {
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscription - подписка на события. Канал C закрывается, когда подписчик отключен:
+// вызовом Close, остановкой Hub или переполнением буфера.
+type Subscription struct {
+	C <-chan Event
+
+	hub    *Hub
+	filter Filter
+	ch     chan Event
+}
+
+// Close отменяет подписку
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.removeLocked(s)
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// timeLayout - формат времени в ячейках выгрузки
+const timeLayout = "2006-01-02 15:04"
+
+// cellKind представляет тип значения ячейки
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellString
+	cellNumber
+)
+
+// cellValue приводит значение ячейки к тексту и определяет, число ли это
+func cellValue(value interface{}) (string, cellKind) {
+	switch v := value.(type) {
+	case nil:
+		return "", cellEmpty
+	case string:
+		return v, cellString
+	case *string:
+		if v == nil {
+			return "", cellEmpty
+		}
+		return *v, cellString
+	case int:
+		return strconv.Itoa(v), cellNumber
+	case *int:
+		if v == nil {
+			return "", cellEmpty
+		}
+		return strconv.Itoa(*v), cellNumber
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), cellNumber
+	case *float64:
+		if v == nil {
+			return "", cellEmpty
+		}
+		return strconv.FormatFloat(*v, 'f', -1, 64), cellNumber
+	case time.Time:
+		return v.Format(timeLayout), cellString
+	case *time.Time:
+		if v == nil {
+			return "", cellEmpty
+		}
+		return v.Format(timeLayout), cellString
+	case fmt.Stringer:
+		return v.String(), cellString
+	default:
+		return fmt.Sprint(v), cellString
+	}
+}
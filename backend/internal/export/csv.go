@@ -0,0 +1,53 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+)
+
+// csvWriter записывает выгрузку в CSV в UTF-8 с BOM, чтобы Excel верно показывал кириллицу
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVWriter(out io.Writer) (*csvWriter, error) {
+	// BOM пишется в общий с csv.Writer буфер, чтобы до первой полной порции в out ничего не попадало
+	buf := bufio.NewWriter(out)
+	if _, err := buf.WriteString("\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(buf)}, nil
+}
+
+// WriteRow записывает строку; буфер сбрасывается в out по мере заполнения
+func (c *csvWriter) WriteRow(values ...interface{}) error {
+	c.record = c.record[:0]
+	for _, value := range values {
+		text, kind := cellValue(value)
+		if kind == cellString {
+			text = escapeFormula(text)
+		}
+		c.record = append(c.record, text)
+	}
+	return c.w.Write(c.record)
+}
+
+// Close сбрасывает оставшиеся строки
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeFormula экранирует текст, который табличный редактор принял бы за формулу
+func escapeFormula(text string) string {
+	if text == "" {
+		return text
+	}
+	switch text[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + text
+	}
+	return text
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "", want: ""},
+		{text: "Покупки", want: "Покупки"},
+		{text: "=SUM(A1:A2)", want: "'=SUM(A1:A2)"},
+		{text: "+79001234567", want: "'+79001234567"},
+		{text: "-1", want: "'-1"},
+		{text: "@cmd", want: "'@cmd"},
+		{text: "\t=1", want: "'\t=1"},
+		{text: "\r=1", want: "'\r=1"},
+		{text: "a=1", want: "a=1"},
+		{text: " =1", want: " =1"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeFormula(tt.text); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatCSV, &out, "")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	rows := [][]interface{}{
+		{"ID", "Название", "Часы"},
+		// Формулы экранируются только в тексте: отрицательное число остается числом
+		{1, "=HYPERLINK(\"x\")", -1.5},
+		{2, "Запятая, \"кавычки\"\nи перенос", nil},
+		{3, (*string)(nil), time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatalf("WriteRow() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := "\ufeff" +
+		"ID,Название,Часы\n" +
+		"1,\"'=HYPERLINK(\"\"x\"\")\",-1.5\n" +
+		"2,\"Запятая, \"\"кавычки\"\"\nи перенос\",\n" +
+		"3,,2024-05-01 18:30\n"
+	if got := out.String(); got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
+
+func TestCSVWriterWritesNothingBeforeFlush(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatCSV, &out, "")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := w.WriteRow("a"); err != nil {
+		t.Fatalf("WriteRow() error = %v", err)
+	}
+
+	// Пока буфер не заполнен, в ответ ничего не пишется и обработчик еще может вернуть ошибку
+	if out.Len() != 0 {
+		t.Errorf("written %d bytes before Close, want 0", out.Len())
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got, want := out.String(), "\ufeffa\n"; got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+// Package export записывает табличные выгрузки в CSV и XLSX построчно,
+// не накапливая всю таблицу в памяти.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format представляет формат выгрузки
+type Format string
+
+// Поддерживаемые форматы выгрузки
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnknownFormat возвращается для неподдерживаемого формата выгрузки
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// ParseFormat разбирает формат выгрузки; пустая строка означает CSV
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+	}
+}
+
+// ContentType возвращает MIME-тип файла выгрузки
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer записывает строки таблицы. Значения ячеек - строки, целые и дробные числа,
+// время, указатели на них или nil для пустой ячейки.
+type Writer interface {
+	WriteRow(values ...interface{}) error
+	// Close дописывает файл; без него выгрузка неполная
+	Close() error
+}
+
+// NewWriter создает Writer выбранного формата. sheet - название листа XLSX.
+func NewWriter(format Format, out io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(out)
+	case FormatXLSX:
+		return newXLSXWriter(out, sheet)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
@@ -0,0 +1,192 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// xlsxTestSheet - лист XLSX, прочитанный обратно из выгрузки
+type xlsxTestSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Type  string `xml:"t,attr"`
+			Value string `xml:"v"`
+			Text  string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// xlsxTestWorkbook - оглавление книги XLSX
+type xlsxTestWorkbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+func TestEscapeXML(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Покупки", want: "Покупки"},
+		{text: `<b>a & "b"</b>`, want: "&lt;b&gt;a &amp; &#34;b&#34;&lt;/b&gt;"},
+		{text: "строка\nстрока", want: "строка&#xA;строка"},
+		// Управляющие символы недопустимы в XML и заменяются
+		{text: "a\x00b\x1fc", want: "a\ufffdb\ufffdc"},
+	}
+
+	for _, tt := range tests {
+		if got := escapeXML(tt.text); got != tt.want {
+			t.Errorf("escapeXML(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestXLSXWriterRoundTrip(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &out, "Заявки <май>")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+
+	slots := 2
+	rows := [][]interface{}{
+		{"ID", "Название", "Места", "Срок"},
+		{1, `=1+1 & <script>`, &slots, time.Date(2024, 5, 1, 18, 30, 0, 0, time.UTC)},
+		{2.5, "a\x00b", (*int)(nil), nil},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row...); err != nil {
+			t.Fatalf("WriteRow() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+
+	var names []string
+	parts := make(map[string][]byte)
+	for _, f := range archive.File {
+		names = append(names, f.Name)
+		parts[f.Name] = readZipFile(t, f)
+		if !isWellFormedXML(parts[f.Name]) {
+			t.Errorf("%s is not well-formed XML", f.Name)
+		}
+	}
+	wantNames := []string{
+		"[Content_Types].xml",
+		"_rels/.rels",
+		"xl/_rels/workbook.xml.rels",
+		"xl/workbook.xml",
+		"xl/worksheets/sheet1.xml",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("parts = %v, want %v", names, wantNames)
+	}
+
+	var workbook xlsxTestWorkbook
+	if err := xml.Unmarshal(parts["xl/workbook.xml"], &workbook); err != nil {
+		t.Fatalf("unmarshal workbook: %v", err)
+	}
+	if len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != "Заявки <май>" {
+		t.Errorf("sheets = %+v, want one sheet %q", workbook.Sheets, "Заявки <май>")
+	}
+
+	var sheet xlsxTestSheet
+	if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+		t.Fatalf("unmarshal sheet: %v", err)
+	}
+
+	// Ячейки в виде "тип:значение": числа - "n:", текст - "s:", пустые - ""
+	var got [][]string
+	for _, row := range sheet.Rows {
+		var cells []string
+		for _, c := range row.Cells {
+			switch {
+			case c.Type == "inlineStr":
+				cells = append(cells, "s:"+c.Text)
+			case c.Value != "":
+				cells = append(cells, "n:"+c.Value)
+			default:
+				cells = append(cells, "")
+			}
+		}
+		got = append(got, cells)
+	}
+	want := [][]string{
+		{"s:ID", "s:Название", "s:Места", "s:Срок"},
+		// В XLSX текст хранится как текст, поэтому формула не экранируется
+		{"n:1", "s:=1+1 & <script>", "n:2", "s:2024-05-01 18:30"},
+		{"n:2.5", "s:a\ufffdb", "", ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("cells = %q, want %q", got, want)
+	}
+}
+
+func TestXLSXWriterEmptySheet(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &out, "Пусто")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	for _, f := range archive.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		var sheet xlsxTestSheet
+		if err := xml.Unmarshal(readZipFile(t, f), &sheet); err != nil {
+			t.Fatalf("unmarshal sheet: %v", err)
+		}
+		if len(sheet.Rows) != 0 {
+			t.Errorf("rows = %d, want 0", len(sheet.Rows))
+		}
+		return
+	}
+	t.Fatal("sheet1.xml not found")
+}
+
+func readZipFile(t *testing.T, f *zip.File) []byte {
+	t.Helper()
+
+	rc, err := f.Open()
+	if err != nil {
+		t.Fatalf("open %s: %v", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read %s: %v", f.Name, err)
+	}
+	return data
+}
+
+// isWellFormedXML проверяет, что документ разбирается XML-парсером целиком
+func isWellFormedXML(data []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			return errors.Is(err, io.EOF)
+		}
+	}
+}
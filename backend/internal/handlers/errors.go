@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// respondWithServiceError преобразует ошибку сервисного слоя в HTTP-ответ
+// с соответствующим статусом. Неизвестные ошибки считаются внутренними.
+func respondWithServiceError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		utils.RespondWithError(w, http.StatusNotFound, message+": "+err.Error())
+	case errors.Is(err, models.ErrForbidden):
+		utils.RespondWithError(w, http.StatusForbidden, message+": "+err.Error())
+	case errors.Is(err, models.ErrUnauthorized):
+		utils.RespondWithError(w, http.StatusUnauthorized, message+": "+err.Error())
+	case errors.Is(err, models.ErrConflict):
+		utils.RespondWithError(w, http.StatusConflict, message+": "+err.Error())
+	case errors.Is(err, models.ErrInvalidRequest):
+		utils.RespondWithError(w, http.StatusBadRequest, message+": "+err.Error())
+	default:
+		utils.RespondWithError(w, http.StatusInternalServerError, message+": "+err.Error())
+	}
+}
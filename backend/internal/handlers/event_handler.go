@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/events"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// eventHeartbeatInterval - как часто в поток отправляется комментарий,
+// чтобы прокси не закрывали соединение без событий
+const eventHeartbeatInterval = 25 * time.Second
+
+// EventHandler отдает поток событий сервера (Server-Sent Events)
+type EventHandler struct {
+	hub       *events.Hub
+	jwtSecret string
+}
+
+// NewEventHandler создает новый экземпляр обработчика потока событий.
+// jwtSecret подписывает и проверяет токены потока событий.
+func NewEventHandler(hub *events.Hub, jwtSecret string) *EventHandler {
+	return &EventHandler{
+		hub:       hub,
+		jwtSecret: jwtSecret,
+	}
+}
+
+// IssueStreamToken выдает короткоживущий токен для подключения к потоку событий
+// @Summary Токен потока событий
+// @Description Токен действует минуту и принимается только потоком событий в параметре ?token=: браузерный EventSource не передает заголовок Authorization. Токен нужен только для установки соединения, перед переподключением запрашивается новый
+// @Tags events
+// @Produce json
+// @Success 200 {object} models.UserToken
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/events/token [post]
+func (h *EventHandler) IssueStreamToken(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	token, expiresAt, err := middleware.GenerateEventStreamToken(h.jwtSecret, userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to issue event stream token")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, models.UserToken{Token: token, ExpiresAt: expiresAt})
+}
+
+// StreamEvents отдает поток событий текущего пользователя
+// @Summary Поток событий
+// @Description Server-Sent Events: notification - новое уведомление пользователя, request_status - новый статус заявки, request_created - новая заявка в ленте (фильтры category и priority). После переподключения с заголовком Last-Event-ID пропущенные события отправляются повторно; если их уже нет, сначала приходит событие reset и клиенту нужно заново загрузить данные
+// @Tags events
+// @Produce text/event-stream
+// @Param category query string false "Только новые заявки категории"
+// @Param priority query string false "Только новые заявки с приоритетом"
+// @Param last_event_id query string false "ID последнего полученного события, если клиент не может передать заголовок Last-Event-ID"
+// @Param token query string false "Токен из POST /api/events/token, если клиент не может передать заголовок Authorization"
+// @Success 200 {string} string "Поток событий"
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 503 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/events [get]
+func (h *EventHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	query := r.URL.Query()
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = query.Get("last_event_id")
+	}
+
+	sub, missed, resumed, err := h.hub.Subscribe(events.Filter{
+		UserID:   userID,
+		Category: query.Get("category"),
+		Priority: query.Get("priority"),
+	}, lastEventID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusServiceUnavailable, "Server is shutting down")
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprint(w, "retry: 5000\n\n")
+	if lastEventID != "" && !resumed {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	for _, e := range missed {
+		if err := writeEvent(w, e); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-sub.C:
+			// Канал закрыт: сервер останавливается или клиент не успевал читать события
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent записывает событие в формате Server-Sent Events
+func writeEvent(w http.ResponseWriter, e events.Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
+
+// RegisterEventRoutes регистрирует выдачу токена потока событий.
+// Маршрут требует авторизации.
+func RegisterEventRoutes(r chi.Router, h *EventHandler) {
+	r.Post("/api/events/token", h.IssueStreamToken)
+}
+
+// RegisterEventStreamRoutes регистрирует поток событий с собственной проверкой токена.
+// Соединение потока открыто долго, поэтому маршрут монтируется вне middleware.Timeout.
+func RegisterEventStreamRoutes(r chi.Router, h *EventHandler) {
+	r.With(middleware.EventStreamAuth(h.jwtSecret)).Get("/api/events", h.StreamEvents)
+}
@@ -0,0 +1,353 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/services"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// PartnerHandler обрабатывает запросы, связанные с партнерскими организациями
+type PartnerHandler struct {
+	partnerService *services.PartnerService
+}
+
+// NewPartnerHandler создает новый экземпляр обработчика партнерских организаций
+func NewPartnerHandler(partnerService *services.PartnerService) *PartnerHandler {
+	return &PartnerHandler{
+		partnerService: partnerService,
+	}
+}
+
+// GetPartners возвращает партнерские организации
+// @Summary Партнерские организации
+// @Description Список партнерских организаций по названию
+// @Tags partners
+// @Produce json
+// @Param verified query bool false "Только проверенные организации"
+// @Success 200 {array} models.PartnerOrganization
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/partners [get]
+func (h *PartnerHandler) GetPartners(w http.ResponseWriter, r *http.Request) {
+	verifiedOnly := r.URL.Query().Get("verified") == "true"
+
+	partners, err := h.partnerService.GetPartners(verifiedOnly)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get partners")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, partners)
+}
+
+// GetPartner возвращает партнерскую организацию
+// @Summary Партнерская организация
+// @Tags partners
+// @Produce json
+// @Param id path int true "ID организации"
+// @Success 200 {object} models.PartnerOrganization
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Router /api/partners/{id} [get]
+func (h *PartnerHandler) GetPartner(w http.ResponseWriter, r *http.Request) {
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	partner, err := h.partnerService.GetPartner(partnerID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get partner")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, partner)
+}
+
+// CreatePartner создает партнерскую организацию
+// @Summary Создать партнерскую организацию
+// @Description Новая организация не проверена: ее сотрудники смогут создавать заявки после проверки
+// @Tags partners
+// @Accept json
+// @Produce json
+// @Param input body models.PartnerInput true "Профиль организации"
+// @Success 201 {object} models.PartnerOrganization
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners [post]
+func (h *PartnerHandler) CreatePartner(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var input models.PartnerInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	partner, err := h.partnerService.CreatePartner(userID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to create partner")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, partner)
+}
+
+// UpdatePartner заменяет профиль партнерской организации
+// @Summary Изменить партнерскую организацию
+// @Description Доступно администраторам и руководителям организации
+// @Tags partners
+// @Accept json
+// @Produce json
+// @Param id path int true "ID организации"
+// @Param input body models.PartnerInput true "Профиль организации"
+// @Success 200 {object} models.PartnerOrganization
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners/{id} [put]
+func (h *PartnerHandler) UpdatePartner(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	var input models.PartnerInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	partner, err := h.partnerService.UpdatePartner(userID, partnerID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to update partner")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, partner)
+}
+
+// SetPartnerVerified отмечает организацию проверенной или снимает отметку
+// @Summary Проверка партнерской организации
+// @Description Сотрудники проверенной организации создают заявки от ее имени, и заявки показывают организацию
+// @Tags partners
+// @Accept json
+// @Produce json
+// @Param id path int true "ID организации"
+// @Param input body models.PartnerVerificationInput true "Отметка"
+// @Success 200 {object} models.PartnerOrganization
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners/{id}/verification [put]
+func (h *PartnerHandler) SetPartnerVerified(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	var input models.PartnerVerificationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	partner, err := h.partnerService.SetPartnerVerified(userID, partnerID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to update partner verification")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, partner)
+}
+
+// DeletePartner удаляет партнерскую организацию
+// @Summary Удалить партнерскую организацию
+// @Description Заявки организации остаются у их авторов
+// @Tags partners
+// @Param id path int true "ID организации"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners/{id} [delete]
+func (h *PartnerHandler) DeletePartner(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	if err := h.partnerService.DeletePartner(userID, partnerID); err != nil {
+		respondWithServiceError(w, err, "Failed to delete partner")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetPartnerMembers возвращает сотрудников партнерской организации
+// @Summary Сотрудники организации
+// @Description Доступно администраторам и сотрудникам организации
+// @Tags partners
+// @Produce json
+// @Param id path int true "ID организации"
+// @Success 200 {array} models.PartnerMember
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners/{id}/members [get]
+func (h *PartnerHandler) GetPartnerMembers(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	members, err := h.partnerService.GetPartnerMembers(userID, partnerID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get partner members")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, members)
+}
+
+// SavePartnerMember добавляет сотрудника в организацию или меняет его роль
+// @Summary Добавить сотрудника организации
+// @Description Доступно администраторам и руководителям организации. По умолчанию сотрудник получает роль staff
+// @Tags partners
+// @Accept json
+// @Produce json
+// @Param id path int true "ID организации"
+// @Param input body models.PartnerMemberInput true "Сотрудник"
+// @Success 200 {array} models.PartnerMember
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners/{id}/members [put]
+func (h *PartnerHandler) SavePartnerMember(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	var input models.PartnerMemberInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	members, err := h.partnerService.SavePartnerMember(userID, partnerID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to save partner member")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, members)
+}
+
+// DeletePartnerMember исключает сотрудника из организации
+// @Summary Исключить сотрудника организации
+// @Description Доступно администраторам и руководителям организации; сотрудник может выйти из организации сам
+// @Tags partners
+// @Param id path int true "ID организации"
+// @Param userId path int true "ID сотрудника"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/partners/{id}/members/{userId} [delete]
+func (h *PartnerHandler) DeletePartnerMember(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	partnerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid partner ID")
+		return
+	}
+
+	memberID, err := strconv.Atoi(chi.URLParam(r, "userId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.partnerService.DeletePartnerMember(userID, partnerID, memberID); err != nil {
+		respondWithServiceError(w, err, "Failed to delete partner member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterPartnerRoutes регистрирует маршруты партнерских организаций, требующие авторизации.
+// Создание, проверка и удаление организаций доступны только администраторам.
+func RegisterPartnerRoutes(r chi.Router, h *PartnerHandler) {
+	r.Put("/api/partners/{id}", h.UpdatePartner)
+	r.Get("/api/partners/{id}/members", h.GetPartnerMembers)
+	r.Put("/api/partners/{id}/members", h.SavePartnerMember)
+	r.Delete("/api/partners/{id}/members/{userId}", h.DeletePartnerMember)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Post("/api/partners", h.CreatePartner)
+		r.Put("/api/partners/{id}/verification", h.SetPartnerVerified)
+		r.Delete("/api/partners/{id}", h.DeletePartner)
+	})
+}
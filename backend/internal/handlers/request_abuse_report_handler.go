@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// CreateAbuseReport принимает жалобу
+// @Summary Пожаловаться
+// @Description Жалоба на заявку, комментарий или пользователя. Причины: fake, spam, abuse, no_show, other (для other обязательно описание). Заявка или комментарий, набравшие REPORT_HIDE_THRESHOLD открытых жалоб, скрываются до решения администратора
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param input body models.AbuseReportInput true "Жалоба"
+// @Success 201 {object} models.AbuseReport
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/reports [post]
+func (h *RequestHandler) CreateAbuseReport(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var input models.AbuseReportInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	report, err := h.requestService.CreateAbuseReport(userID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to create report")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, report)
+}
+
+// GetAbuseReports возвращает очередь жалоб
+// @Summary Очередь жалоб
+// @Description Жалобы в выбранном статусе, самые давние первыми. targetReports - число открытых жалоб на тот же объект, targetHidden - объект скрыт или пользователь заблокирован
+// @Tags reports
+// @Produce json
+// @Param status query string false "Статус жалоб: open, dismissed, actioned" default(open)
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество жалоб на странице"
+// @Success 200 {object} models.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/reports [get]
+func (h *RequestHandler) GetAbuseReports(w http.ResponseWriter, r *http.Request) {
+	limit, offset := utils.PaginationParams(r, 20, 100)
+	page := 1
+	if offset > 0 {
+		page = (offset / limit) + 1
+	}
+
+	status := models.AbuseReportStatus(r.URL.Query().Get("status"))
+	if status == "" {
+		status = models.AbuseReportOpen
+	}
+
+	reports, totalCount, err := h.requestService.GetAbuseReports(status, limit, offset)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get reports")
+		return
+	}
+
+	response := models.PaginatedResponse{
+		Items:      reports,
+		TotalItems: totalCount,
+		TotalPages: (totalCount + limit - 1) / limit,
+		Page:       page,
+		PageSize:   limit,
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// ResolveAbuseReport применяет решение по жалобе
+// @Summary Решение по жалобе
+// @Description Решение применяется ко всем открытым жалобам на тот же объект. dismiss - отклонить жалобы (контент, скрытый автоматически, снова показывается), hide - скрыть заявку или комментарий, suspend - заблокировать пользователя или автора контента и скрыть контент
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path int true "ID жалобы"
+// @Param input body models.AbuseReportResolveInput true "Решение"
+// @Success 200 {object} models.AbuseReport
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/reports/{id}/resolve [post]
+func (h *RequestHandler) ResolveAbuseReport(w http.ResponseWriter, r *http.Request) {
+	adminID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	reportID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid report ID")
+		return
+	}
+
+	var input models.AbuseReportResolveInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	report, err := h.requestService.ResolveAbuseReport(adminID, reportID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to resolve report")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// UnsuspendUser снимает блокировку пользователя
+// @Summary Снять блокировку пользователя
+// @Description Пользователь снова может создавать и брать заявки, писать комментарии и сообщения
+// @Tags reports
+// @Param id path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/users/{id}/suspension [delete]
+func (h *RequestHandler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	adminID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.requestService.UnsuspendUser(adminID, userID); err != nil {
+		respondWithServiceError(w, err, "Failed to unsuspend user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterAbuseReportRoutes регистрирует маршруты жалоб.
+// Жалобу может отправить любой авторизованный пользователь, очередь и решения доступны только администраторам.
+func RegisterAbuseReportRoutes(r chi.Router, h *RequestHandler) {
+	r.Post("/api/reports", h.CreateAbuseReport)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Get("/api/admin/reports", h.GetAbuseReports)
+		r.Post("/api/admin/reports/{id}/resolve", h.ResolveAbuseReport)
+		r.Delete("/api/admin/users/{id}/suspension", h.UnsuspendUser)
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// UpdateComment изменяет текст комментария
+// @Summary Изменение комментария
+// @Description Изменяет текст комментария. Доступно только автору; комментарий отмечается измененным (editedAt)
+// @Tags requests
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param commentId path int true "ID комментария"
+// @Param comment body models.RequestCommentUpdateInput true "Новый текст комментария"
+// @Success 200 {object} models.RequestComment
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/comments/{commentId} [put]
+func (h *RequestHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, commentID, ok := commentIDsFromURL(w, r)
+	if !ok {
+		return
+	}
+
+	var input models.RequestCommentUpdateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	comment, err := h.requestService.UpdateComment(userID, requestID, commentID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to update comment")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, comment)
+}
+
+// DeleteComment удаляет комментарий
+// @Summary Удаление комментария
+// @Description Автор удаляет свой комментарий, администратор - любой. Комментарий с ответами и комментарий, удаленный администратором, остаются в ветке без текста
+// @Tags requests
+// @Param id path int true "ID заявки"
+// @Param commentId path int true "ID комментария"
+// @Success 204
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/comments/{commentId} [delete]
+func (h *RequestHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, commentID, ok := commentIDsFromURL(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.requestService.DeleteComment(userID, requestID, commentID); err != nil {
+		respondWithServiceError(w, err, "Failed to delete comment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// commentIDsFromURL разбирает ID заявки и комментария из пути; при ошибке отвечает 400
+func commentIDsFromURL(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return 0, 0, false
+	}
+
+	commentID, err := strconv.Atoi(chi.URLParam(r, "commentId"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid comment ID")
+		return 0, 0, false
+	}
+
+	return requestID, commentID, true
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// AssignRequest назначает волонтера на заявку
+// @Summary Назначить волонтера
+// @Description Координатор закрепляет волонтера за свободным местом заявки. У волонтера не может быть больше 5 незавершенных заявок
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param input body models.RequestAssignInput true "Волонтер"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/assign [post]
+func (h *RequestHandler) AssignRequest(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	var input models.RequestAssignInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	request, err := h.requestService.AssignRequest(userID, requestID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to assign request")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, request)
+}
+
+// ReassignRequest передает заявку от одного волонтера другому
+// @Summary Переназначить заявку
+// @Description Координатор передает место волонтера в заявке другому волонтеру; статус заявки не меняется
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param input body models.RequestReassignInput true "Волонтеры и причина"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/reassign [post]
+func (h *RequestHandler) ReassignRequest(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	var input models.RequestReassignInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	request, err := h.requestService.ReassignRequest(userID, requestID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to reassign request")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, request)
+}
+
+// BulkAssignRequests назначает волонтера сразу на несколько заявок
+// @Summary Назначить волонтера на несколько заявок
+// @Description Заявки назначаются независимо, результат возвращается по каждой заявке
+// @Tags dispatch
+// @Accept json
+// @Produce json
+// @Param input body models.RequestBulkAssignInput true "Заявки и волонтер"
+// @Success 200 {array} models.RequestBulkAssignResult
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/bulk-assign [post]
+func (h *RequestHandler) BulkAssignRequests(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var input models.RequestBulkAssignInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	results, err := h.requestService.BulkAssignRequests(userID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to assign requests")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, results)
+}
+
+// GetVolunteerLoads возвращает загрузку волонтеров
+// @Summary Загрузка волонтеров
+// @Description Волонтеры с числом незавершенных заявок, начиная с наименее загруженных
+// @Tags dispatch
+// @Produce json
+// @Success 200 {array} models.VolunteerLoad
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/dispatch/volunteers [get]
+func (h *RequestHandler) GetVolunteerLoads(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	loads, err := h.requestService.GetVolunteerLoads(userID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get volunteer loads")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, loads)
+}
+
+// RegisterRequestDispatchRoutes регистрирует маршруты назначения волонтеров координатором.
+// Все маршруты доступны только администраторам.
+func RegisterRequestDispatchRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Post("/api/requests/{id}/assign", h.AssignRequest)
+		r.Post("/api/requests/{id}/reassign", h.ReassignRequest)
+		r.Post("/api/requests/bulk-assign", h.BulkAssignRequests)
+		r.Get("/api/dispatch/volunteers", h.GetVolunteerLoads)
+	})
+}
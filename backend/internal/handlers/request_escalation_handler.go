@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetEscalationRules возвращает правила эскалации заявок
+// @Summary Правила эскалации
+// @Description Пороги ожидания волонтера по приоритету; правило категории важнее общего правила
+// @Tags escalations
+// @Produce json
+// @Success 200 {array} models.EscalationRule
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/escalation-rules [get]
+func (h *RequestHandler) GetEscalationRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.requestService.GetEscalationRules()
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get escalation rules")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, rules)
+}
+
+// SaveEscalationRule создает или заменяет правило эскалации
+// @Summary Сохранить правило эскалации
+// @Description Заменяет правило с той же категорией и приоритетом, если оно уже есть
+// @Tags escalations
+// @Accept json
+// @Produce json
+// @Param input body models.EscalationRuleInput true "Правило эскалации"
+// @Success 200 {object} models.EscalationRule
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/escalation-rules [put]
+func (h *RequestHandler) SaveEscalationRule(w http.ResponseWriter, r *http.Request) {
+	var input models.EscalationRuleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	rule, err := h.requestService.SaveEscalationRule(input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to save escalation rule")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, rule)
+}
+
+// DeleteEscalationRule удаляет правило эскалации
+// @Summary Удалить правило эскалации
+// @Tags escalations
+// @Param id path int true "ID правила"
+// @Success 204
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/escalation-rules/{id} [delete]
+func (h *RequestHandler) DeleteEscalationRule(w http.ResponseWriter, r *http.Request) {
+	ruleID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid rule ID")
+		return
+	}
+
+	if err := h.requestService.DeleteEscalationRule(ruleID); err != nil {
+		respondWithServiceError(w, err, "Failed to delete escalation rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetRequestEscalations возвращает журнал эскалаций заявки
+// @Summary Эскалации заявки
+// @Description Когда и почему заявка эскалировалась: порог, время ожидания, смена приоритета
+// @Tags escalations
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 200 {array} models.RequestEscalation
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/escalations [get]
+func (h *RequestHandler) GetRequestEscalations(w http.ResponseWriter, r *http.Request) {
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	escalations, err := h.requestService.GetRequestEscalations(requestID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get request escalations")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, escalations)
+}
+
+// RegisterRequestEscalationRoutes регистрирует маршруты эскалации заявок.
+// Все маршруты доступны только администраторам.
+func RegisterRequestEscalationRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Get("/api/escalation-rules", h.GetEscalationRules)
+		r.Put("/api/escalation-rules", h.SaveEscalationRule)
+		r.Delete("/api/escalation-rules/{id}", h.DeleteEscalationRule)
+		r.Get("/api/requests/{id}/escalations", h.GetRequestEscalations)
+	})
+}
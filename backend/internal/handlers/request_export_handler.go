@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/export"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// periodDateLayout - формат дат периода в параметрах выгрузок и отчетов
+const periodDateLayout = "2006-01-02"
+
+// ExportRequests выгружает заявки в CSV или XLSX
+// @Summary Выгрузка заявок
+// @Description Выгружает заявки с теми же фильтрами, что и лента, без пагинации. Файл передается по мере чтения заявок из базы
+// @Tags requests
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат: csv (по умолчанию) или xlsx"
+// @Param status query string false "Фильтр по статусу"
+// @Param category query string false "Фильтр по категории"
+// @Param priority query string false "Фильтр по приоритету"
+// @Param partner_id query int false "Фильтр по партнерской организации"
+// @Param from query string false "Созданы не раньше даты (YYYY-MM-DD)"
+// @Param to query string false "Созданы не позже даты включительно (YYYY-MM-DD)"
+// @Success 200 {file} file
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/exports/requests [get]
+func (h *RequestHandler) ExportRequests(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	format, err := export.ParseFormat(query.Get("format"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid format")
+		return
+	}
+	from, to, err := periodFromQuery(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid period: "+err.Error())
+		return
+	}
+
+	partnerID, _ := strconv.Atoi(query.Get("partner_id"))
+	filter := models.RequestFilter{
+		Status:    query.Get("status"),
+		Category:  query.Get("category"),
+		Priority:  query.Get("priority"),
+		PartnerID: partnerID,
+	}
+	if !from.IsZero() {
+		filter.CreatedFrom = &from
+	}
+	if !to.IsZero() {
+		filter.CreatedTo = &to
+	}
+
+	out := newExportResponseWriter(w, format, "requests")
+	err = h.requestService.ExportRequests(r.Context(), userID, filter, format, out)
+	h.finishExport(out, err, "Failed to export requests")
+}
+
+// ExportVolunteerActivity выгружает активность волонтеров за период
+// @Summary Выгрузка активности волонтеров
+// @Description Для каждого волонтера с активностью в периоде: выполненные заявки, засчитанные часы, число и средняя оценок от авторов заявок. По умолчанию - текущий месяц
+// @Tags requests
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат: csv (по умолчанию) или xlsx"
+// @Param from query string false "Начало периода (YYYY-MM-DD)"
+// @Param to query string false "Конец периода включительно (YYYY-MM-DD)"
+// @Param partner_id query int false "Учитывать только заявки партнерской организации"
+// @Success 200 {file} file
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/exports/volunteer-activity [get]
+func (h *RequestHandler) ExportVolunteerActivity(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	format, err := export.ParseFormat(query.Get("format"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid format")
+		return
+	}
+	from, to, err := periodFromQuery(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid period: "+err.Error())
+		return
+	}
+
+	now := time.Now()
+	if from.IsZero() {
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 1, 0)
+	}
+
+	partnerID, _ := strconv.Atoi(query.Get("partner_id"))
+	filter := models.VolunteerActivityFilter{
+		From:      from,
+		To:        to,
+		PartnerID: partnerID,
+	}
+
+	out := newExportResponseWriter(w, format, "volunteer-activity")
+	err = h.requestService.ExportVolunteerActivity(r.Context(), userID, filter, format, out)
+	h.finishExport(out, err, "Failed to export volunteer activity")
+}
+
+// finishExport сообщает об ошибке выгрузки. Пока файл не начал передаваться, клиент получает
+// обычный ответ с ошибкой; после этого заголовки уже отправлены и ошибка только логируется.
+func (h *RequestHandler) finishExport(out *exportResponseWriter, err error, message string) {
+	if err == nil {
+		if !out.started {
+			// Пустая выгрузка без единой строки все равно отдается файлом
+			out.Write(nil)
+		}
+		return
+	}
+	if !out.started {
+		respondWithServiceError(out.w, err, message)
+		return
+	}
+	h.logger.WithError(err).Error(message)
+}
+
+// periodFromQuery разбирает параметры from и to. Дата to включается в период,
+// поэтому возвращается начало следующего дня. Отсутствующая дата возвращается нулевой.
+func periodFromQuery(r *http.Request) (time.Time, time.Time, error) {
+	var from, to time.Time
+	query := r.URL.Query()
+
+	if value := query.Get("from"); value != "" {
+		date, err := time.ParseInLocation(periodDateLayout, value, time.Local)
+		if err != nil {
+			return from, to, errors.New("from must be a date in YYYY-MM-DD format")
+		}
+		from = date
+	}
+
+	if value := query.Get("to"); value != "" {
+		date, err := time.ParseInLocation(periodDateLayout, value, time.Local)
+		if err != nil {
+			return from, to, errors.New("to must be a date in YYYY-MM-DD format")
+		}
+		to = date.AddDate(0, 0, 1)
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return from, to, errors.New("from is after to")
+	}
+
+	return from, to, nil
+}
+
+// exportResponseWriter откладывает заголовки файла до первой записи, чтобы ошибки,
+// возникшие до начала выгрузки (например, нет прав), можно было отдать обычным ответом
+type exportResponseWriter struct {
+	w        http.ResponseWriter
+	format   export.Format
+	filename string
+	started  bool
+}
+
+func newExportResponseWriter(w http.ResponseWriter, format export.Format, name string) *exportResponseWriter {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format(periodDateLayout), format)
+	return &exportResponseWriter{w: w, format: format, filename: filename}
+}
+
+// Write отправляет заголовки файла перед первой порцией данных
+func (e *exportResponseWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		e.w.Header().Set("Content-Type", e.format.ContentType())
+		e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, e.filename))
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
+
+// RegisterRequestExportRoutes регистрирует маршруты выгрузок.
+// Все маршруты доступны только администраторам.
+func RegisterRequestExportRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Get("/api/admin/exports/requests", h.ExportRequests)
+		r.Get("/api/admin/exports/volunteer-activity", h.ExportVolunteerActivity)
+	})
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/kal9mov/moshosp/backend/internal/middleware"
@@ -138,7 +140,7 @@ func (h *RequestHandler) CreateRequest(w http.ResponseWriter, r *http.Request) {
 
 // UpdateRequest обновляет заявку
 // @Summary Обновить заявку
-// @Description Обновляет заявку (доступно только создателю или волонтеру)
+// @Description Обновляет заявку (доступно автору или администратору). Статус можно сменить на completed или cancelled; new и in_progress выставляются взятием и освобождением заявки
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -354,34 +356,14 @@ func (h *RequestHandler) CompleteRequest(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Проверяем, имеет ли пользователь доступ к заявке
-	hasAccess, err := h.requestService.UserHasAccessToRequest(r.Context(), userID, requestID)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to check access: "+err.Error())
-		return
-	}
-
-	if !hasAccess {
-		utils.RespondWithError(w, http.StatusForbidden, "You don't have permission to complete this request")
-		return
-	}
-
-	// Завершаем заявку
-	completedRequest, err := h.requestService.CompleteRequest(r.Context(), requestID, userID)
+	// Завершаем заявку; права и допустимость перехода проверяет машина состояний,
+	// опыт волонтеру начисляется в сервисе
+	completedRequest, err := h.requestService.CompleteRequest(userID, requestID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to complete request: "+err.Error())
+		respondWithServiceError(w, err, "Failed to complete request")
 		return
 	}
 
-	// Добавляем опыт волонтеру за выполнение заявки
-	if completedRequest.VolunteerID != nil {
-		_, err = h.gameService.AddExperience(r.Context(), *completedRequest.VolunteerID, 50)
-		if err != nil {
-			// Логируем ошибку, но не прерываем выполнение
-			// TODO: добавить логирование
-		}
-	}
-
 	utils.RespondWithJSON(w, http.StatusOK, completedRequest)
 }
 
@@ -439,13 +421,15 @@ func (h *RequestHandler) AcceptRequest(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path int true "ID заявки"
+// @Param input body models.RequestStatusChangeInput false "Причина отмены"
 // @Success 200 {object} models.RequestFullInfo
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /api/requests/{id} [delete]
+// @Router /api/requests/{id}/cancel [post]
 func (h *RequestHandler) CancelRequest(w http.ResponseWriter, r *http.Request) {
 	// Получаем ID пользователя из контекста
 	userID, err := utils.GetUserIDFromContext(r.Context())
@@ -462,10 +446,17 @@ func (h *RequestHandler) CancelRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Причина отмены необязательна, поэтому пустое тело допустимо
+	var input models.RequestStatusChangeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && err != io.EOF {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
 	// Отменяем заявку
-	request, err := h.requestService.CancelRequest(r.Context(), requestID, userID)
+	request, err := h.requestService.CancelRequest(userID, requestID, input.Reason)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to cancel request: "+err.Error())
+		respondWithServiceError(w, err, "Failed to cancel request")
 		return
 	}
 
@@ -474,7 +465,7 @@ func (h *RequestHandler) CancelRequest(w http.ResponseWriter, r *http.Request) {
 
 // AddRequestComment добавляет комментарий к заявке
 // @Summary Добавить комментарий
-// @Description Добавляет комментарий к заявке
+// @Description Добавляет комментарий к заявке. С parentId - ответ на комментарий; ответ на ответ попадает в ту же ветку
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -523,9 +514,9 @@ func (h *RequestHandler) AddRequestComment(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Добавляем комментарий
-	comment, err := h.requestService.AddRequestComment(r.Context(), requestID, userID, &input)
+	comment, err := h.requestService.AddComment(userID, requestID, input)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to add comment: "+err.Error())
+		respondWithServiceError(w, err, "Failed to add comment")
 		return
 	}
 
@@ -543,46 +534,28 @@ func (h *RequestHandler) AddRequestComment(w http.ResponseWriter, r *http.Reques
 // @Param priority query string false "Приоритет заявки (low, medium, high)"
 // @Param limit query int false "Лимит количества записей" default(10)
 // @Param offset query int false "Смещение для пагинации" default(0)
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Param sort query string false "Порядок: по умолчанию или due_soon - сначала ближайший срок"
+// @Param partner_id query int false "Только заявки проверенной партнерской организации"
 // @Success 200 {object} models.PaginatedResponse
 // @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/requests [get]
 func (h *RequestHandler) GetAllRequests(w http.ResponseWriter, r *http.Request) {
-	// Получаем параметры запроса
-	limit, offset := utils.PaginationParams(r, 10, 100)
-
-	// Фильтры
-	status := r.URL.Query().Get("status")
-	category := r.URL.Query().Get("category")
-	priority := r.URL.Query().Get("priority")
+	filter := requestFilterFromQuery(r)
 
-	// Получаем список заявок
-	requests, total, err := h.requestService.GetRequests(r.Context(), limit, offset, status, category, priority)
+	list, err := h.requestService.GetRequests(filter)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get requests: "+err.Error())
+		respondWithServiceError(w, err, "Failed to get requests")
 		return
 	}
 
-	// Формируем ответ с пагинацией
-	totalPages := total / limit
-	if total%limit > 0 {
-		totalPages++
-	}
-
-	response := models.PaginatedResponse{
-		Items:      requests,
-		TotalItems: total,
-		TotalPages: totalPages,
-		Page:       offset/limit + 1,
-		PageSize:   limit,
-	}
-
-	utils.RespondWithJSON(w, http.StatusOK, response)
+	respondWithRequestList(w, list, filter)
 }
 
 // GetRequestByID получает заявку по ID
 // @Summary Получить заявку по ID
-// @Description Возвращает полную информацию о заявке по ее ID
+// @Description Возвращает полную информацию о заявке по ее ID. Заявки на проверке, на доработке и отклоненные видны только автору и администраторам
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -601,10 +574,13 @@ func (h *RequestHandler) GetRequestByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Получаем ID пользователя из контекста (если есть)
+	userID, _ := utils.GetUserIDFromContext(r.Context())
+
 	// Получаем информацию о заявке
-	request, err := h.requestService.GetRequestByID(r.Context(), id)
+	request, err := h.requestService.GetRequestByID(userID, id)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusNotFound, "Request not found")
+		respondWithServiceError(w, err, "Failed to get request")
 		return
 	}
 
@@ -640,6 +616,8 @@ func (h *RequestHandler) GetRequestCategories(w http.ResponseWriter, r *http.Req
 // @Param status query string false "Статус запроса"
 // @Param page query int false "Номер страницы"
 // @Param limit query int false "Количество элементов на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Param sort query string false "Порядок: по умолчанию или due_soon - сначала ближайший срок"
 // @Success 200 {object} models.PaginatedResponse
 // @Failure 401 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
@@ -653,48 +631,22 @@ func (h *RequestHandler) GetUserRequests(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Получение параметров запроса
-	status := r.URL.Query().Get("status")
-
-	// Получение параметров пагинации с дефолтными значениями
-	page := 1
-	limit := 10
-
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		if pageVal, err := strconv.Atoi(pageStr); err == nil && pageVal > 0 {
-			page = pageVal
-		}
-	}
-
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if limitVal, err := strconv.Atoi(limitStr); err == nil && limitVal > 0 {
-			limit = limitVal
-		}
-	}
+	filter := requestFilterFromQuery(r)
 
 	// Получение списка запросов пользователя через сервис
-	requests, total, err := h.requestService.GetUserRequests(userID, status, page, limit)
+	list, err := h.requestService.GetUserRequests(userID, filter)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get user requests")
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get user requests", err.Error())
+		respondWithServiceError(w, err, "Failed to get user requests")
 		return
 	}
 
-	// Формирование ответа с пагинацией
-	response := models.PaginatedResponse{
-		Data:       requests,
-		TotalItems: total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: (total + limit - 1) / limit,
-	}
-
-	utils.RespondWithJSON(w, http.StatusOK, response)
+	respondWithRequestList(w, list, filter)
 }
 
 // RateRequest добавляет оценку выполненной заявке
 // @Summary Оценка выполненной заявки
-// @Description Добавляет оценку выполненной заявке (доступно только создателю заявки)
+// @Description Автор заявки оценивает волонтера (ratedId обязателен, если волонтеров несколько), волонтер - автора заявки. Каждый участник оценивает другого один раз; рейтинг оцененного пользователя пересчитывается
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -705,8 +657,10 @@ func (h *RequestHandler) GetUserRequests(w http.ResponseWriter, r *http.Request)
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
-// @Router /api/requests/{id}/rating [post]
+// @Security BearerAuth
+// @Router /api/requests/{id}/rate [post]
 func (h *RequestHandler) RateRequest(w http.ResponseWriter, r *http.Request) {
 	// Получаем ID пользователя из контекста
 	userID, err := utils.GetUserIDFromContext(r.Context())
@@ -737,9 +691,9 @@ func (h *RequestHandler) RateRequest(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Добавляем оценку
-	rating, err := h.requestService.RateRequest(r.Context(), requestID, userID, &input)
+	rating, err := h.requestService.RateRequest(userID, requestID, input)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to rate request: "+err.Error())
+		respondWithServiceError(w, err, "Failed to rate request")
 		return
 	}
 
@@ -768,7 +722,7 @@ func (h *RequestHandler) GetRequestStats(w http.ResponseWriter, r *http.Request)
 
 // GetRequestComments возвращает комментарии к заявке
 // @Summary Получение комментариев к заявке
-// @Description Возвращает список комментариев к заявке
+// @Description Возвращает ветки комментариев к заявке: комментарии верхнего уровня с ответами в поле replies. Пагинация идет по комментариям верхнего уровня. Измененные комментарии отмечены editedAt, удаленные возвращаются без текста с deletedAt
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -797,16 +751,13 @@ func (h *RequestHandler) GetRequestComments(w http.ResponseWriter, r *http.Reque
 		page = (offset / limit) + 1
 	}
 
-	// Создаем фильтр
-	filter := models.RequestFilter{
-		Limit:  limit,
-		Offset: offset,
-	}
+	// Получаем ID пользователя из контекста (если есть)
+	userID, _ := utils.GetUserIDFromContext(r.Context())
 
-	// Получаем комментарии
-	comments, totalCount, err := h.requestService.GetRequestComments(r.Context(), requestID, filter)
+	// Получаем ветки комментариев
+	comments, totalCount, err := h.requestService.GetRequestComments(userID, requestID, limit, offset)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get comments: "+err.Error())
+		respondWithServiceError(w, err, "Failed to get comments")
 		return
 	}
 
@@ -823,7 +774,7 @@ func (h *RequestHandler) GetRequestComments(w http.ResponseWriter, r *http.Reque
 
 // AddComment добавляет комментарий к заявке
 // @Summary Добавление комментария к заявке
-// @Description Добавляет комментарий к заявке (доступно создателю и волонтеру)
+// @Description Добавляет комментарий к заявке (доступно создателю и волонтеру). С parentId - ответ на комментарий; ответ на ответ попадает в ту же ветку
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -860,9 +811,9 @@ func (h *RequestHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Добавляем комментарий
-	comment, err := h.requestService.AddComment(r.Context(), requestID, userID, &input)
+	comment, err := h.requestService.AddComment(userID, requestID, input)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to add comment: "+err.Error())
+		respondWithServiceError(w, err, "Failed to add comment")
 		return
 	}
 
@@ -871,7 +822,7 @@ func (h *RequestHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 
 // TakeRequest позволяет волонтеру взять заявку на выполнение
 // @Summary Взятие заявки волонтером
-// @Description Волонтер берет заявку на выполнение
+// @Description Волонтер занимает свободное место в заявке. Заявка переходит в работу, когда заняты все места
 // @Tags requests
 // @Accept json
 // @Produce json
@@ -881,6 +832,7 @@ func (h *RequestHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 // @Failure 401 {object} utils.ErrorResponse
 // @Failure 403 {object} utils.ErrorResponse
 // @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/requests/{id}/take [post]
 func (h *RequestHandler) TakeRequest(w http.ResponseWriter, r *http.Request) {
@@ -899,16 +851,87 @@ func (h *RequestHandler) TakeRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Берем заявку на выполнение
-	request, err := h.requestService.TakeRequest(r.Context(), requestID, userID)
+	// Берем заявку на выполнение; если ее уже взял другой волонтер, вернется 409
+	request, err := h.requestService.TakeRequest(userID, requestID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to take request: "+err.Error())
+		respondWithServiceError(w, err, "Failed to take request")
 		return
 	}
 
 	utils.RespondWithJSON(w, http.StatusOK, request)
 }
 
+// GetRequestHistory возвращает историю переходов заявки между статусами
+// @Summary История статусов заявки
+// @Description Возвращает все переходы заявки между статусами с автором, причиной и временем
+// @Tags requests
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 200 {array} models.RequestStatusHistory
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/requests/{id}/history [get]
+func (h *RequestHandler) GetRequestHistory(w http.ResponseWriter, r *http.Request) {
+	// Получаем ID заявки из URL
+	requestIDStr := chi.URLParam(r, "id")
+	requestID, err := strconv.Atoi(requestIDStr)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	// Получаем ID пользователя из контекста (если есть)
+	userID, _ := utils.GetUserIDFromContext(r.Context())
+
+	history, err := h.requestService.GetRequestHistory(userID, requestID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get request history")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, history)
+}
+
+// requestFilterFromQuery читает фильтры и параметры пагинации ленты заявок из запроса.
+// Лента листается курсором (?cursor=), смещение (?page=, ?offset=) оставлено для админских таблиц.
+func requestFilterFromQuery(r *http.Request) models.RequestFilter {
+	limit, offset := utils.PaginationParams(r, 10, 100)
+	query := r.URL.Query()
+
+	// Получаем ID пользователя из контекста (если есть)
+	userID, _ := utils.GetUserIDFromContext(r.Context())
+
+	// Некорректный partner_id не ограничивает ленту, как и отсутствующий
+	partnerID, _ := strconv.Atoi(query.Get("partner_id"))
+
+	return models.RequestFilter{
+		Status:    query.Get("status"),
+		Category:  query.Get("category"),
+		Priority:  query.Get("priority"),
+		Sort:      models.RequestSort(query.Get("sort")),
+		PartnerID: partnerID,
+		UserID:    userID,
+		Cursor:    query.Get("cursor"),
+		Limit:     limit,
+		Offset:    offset,
+	}
+}
+
+// respondWithRequestList отправляет страницу ленты заявок с курсором следующей страницы
+func respondWithRequestList(w http.ResponseWriter, list *models.RequestList, filter models.RequestFilter) {
+	response := models.PaginatedResponse{
+		Items:      list.Items,
+		TotalItems: list.Total,
+		TotalPages: (list.Total + filter.Limit - 1) / filter.Limit,
+		Page:       filter.Offset/filter.Limit + 1,
+		PageSize:   filter.Limit,
+		NextCursor: list.NextCursor,
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
 // RegisterRequestRoutes регистрирует маршруты для обработчика заявок
 func RegisterRequestRoutes(r chi.Router, h *RequestHandler) {
 	// Публичные маршруты
@@ -922,6 +945,9 @@ func RegisterRequestRoutes(r chi.Router, h *RequestHandler) {
 		r.Get("/api/requests", h.GetAllRequests)
 		r.Post("/api/requests", h.CreateRequest)
 		r.Get("/api/requests/user", h.GetUserRequests)
+		r.Get("/api/requests/nearby", h.GetNearbyRequests)
+		r.Get("/api/requests/recommended", h.GetRecommendedRequests)
+		r.Get("/api/requests/search", h.SearchRequests)
 		r.Get("/api/requests/{id}", h.GetRequestByID)
 		r.Put("/api/requests/{id}", h.UpdateRequest)
 		r.Post("/api/requests/{id}/accept", h.AcceptRequest)
@@ -929,8 +955,18 @@ func RegisterRequestRoutes(r chi.Router, h *RequestHandler) {
 		r.Post("/api/requests/{id}/cancel", h.CancelRequest)
 		r.Get("/api/requests/{id}/comments", h.GetRequestComments)
 		r.Post("/api/requests/{id}/comments", h.AddRequestComment)
+		r.Put("/api/requests/{id}/comments/{commentId}", h.UpdateComment)
+		r.Delete("/api/requests/{id}/comments/{commentId}", h.DeleteComment)
 		r.Post("/api/requests/{id}/rate", h.RateRequest)
 		r.Post("/api/requests/{id}/take", h.TakeRequest)
+		r.Post("/api/requests/{id}/release", h.ReleaseRequest)
+		r.Post("/api/requests/{id}/handover/accept", h.AcceptRequestHandover)
+		r.Post("/api/requests/{id}/handover/decline", h.DeclineRequestHandover)
+		r.Post("/api/requests/{id}/checkin", h.CheckIn)
+		r.Post("/api/requests/{id}/checkout", h.CheckOut)
+		r.Get("/api/requests/{id}/time-entries", h.GetRequestTimeEntries)
+		r.Get("/api/requests/{id}/history", h.GetRequestHistory)
+		r.Put("/api/users/me/home-location", h.SetHomeLocation)
 	})
 }
 
@@ -944,41 +980,157 @@ func RegisterRequestRoutes(r chi.Router, h *RequestHandler) {
 // @Param category query string false "Категория заявки"
 // @Param page query int false "Номер страницы"
 // @Param limit query int false "Количество заявок на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Param sort query string false "Порядок: по умолчанию или due_soon - сначала ближайший срок"
+// @Param partner_id query int false "Только заявки проверенной партнерской организации"
 // @Success 200 {object} models.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
 // @Failure 500 {object} utils.ErrorResponse
 // @Router /api/requests [get]
 func (h *RequestHandler) GetRequests(w http.ResponseWriter, r *http.Request) {
+	filter := requestFilterFromQuery(r)
+
+	list, err := h.requestService.GetRequests(filter)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get requests")
+		return
+	}
+
+	respondWithRequestList(w, list, filter)
+}
+
+// maxSearchQueryLength - максимальная длина поисковой строки
+const maxSearchQueryLength = 200
+
+// SearchRequests выполняет полнотекстовый поиск заявок
+// @Summary Поиск заявок
+// @Description Полнотекстовый поиск по заголовку, описанию и адресу заявок. Результаты отсортированы по релевантности, совпадения во фрагментах выделены тегами <mark>
+// @Tags requests
+// @Accept json
+// @Produce json
+// @Param q query string true "Поисковая строка"
+// @Param status query string false "Статус заявки"
+// @Param category query string false "Категория заявки"
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество заявок на странице"
+// @Success 200 {object} models.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/requests/search [get]
+func (h *RequestHandler) SearchRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" || len(q) > maxSearchQueryLength {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid search query")
+		return
+	}
+
 	// Получаем параметры пагинации
 	limit, offset := utils.PaginationParams(r, 10, 100)
-	page := 1
-	if offset > 0 {
-		page = (offset / limit) + 1
+	page := (offset / limit) + 1
+
+	userID, _ := utils.GetUserIDFromContext(r.Context())
+
+	filter := models.RequestSearchFilter{
+		RequestFilter: models.RequestFilter{
+			Status:   query.Get("status"),
+			Category: query.Get("category"),
+			UserID:   userID,
+			Limit:    limit,
+			Offset:   offset,
+		},
+		Query: q,
 	}
 
-	// Получаем параметры фильтрации
-	status := r.URL.Query().Get("status")
-	category := r.URL.Query().Get("category")
+	results, totalCount, err := h.requestService.SearchRequests(filter)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to search requests")
+		return
+	}
+
+	response := models.PaginatedResponse{
+		Items:      results,
+		TotalItems: totalCount,
+		TotalPages: (totalCount + limit - 1) / limit,
+		Page:       page,
+		PageSize:   limit,
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// Радиус поиска заявок рядом, км
+const (
+	defaultNearbyRadiusKm = 5.0
+	maxNearbyRadiusKm     = 50.0
+)
+
+// GetNearbyRequests возвращает заявки рядом с указанной точкой
+// @Summary Заявки рядом
+// @Description Возвращает заявки в заданном радиусе от точки, ближайшие первыми. По умолчанию только открытые заявки
+// @Tags requests
+// @Accept json
+// @Produce json
+// @Param lat query number true "Широта"
+// @Param lon query number true "Долгота"
+// @Param radius_km query number false "Радиус поиска в км (по умолчанию 5, максимум 50)"
+// @Param status query string false "Статус заявки"
+// @Param category query string false "Категория заявки"
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество заявок на странице"
+// @Success 200 {object} models.PaginatedResponse
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Router /api/requests/nearby [get]
+func (h *RequestHandler) GetNearbyRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid latitude")
+		return
+	}
+
+	lon, err := strconv.ParseFloat(query.Get("lon"), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid longitude")
+		return
+	}
+
+	radius := defaultNearbyRadiusKm
+	if radiusStr := query.Get("radius_km"); radiusStr != "" {
+		radius, err = strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radius <= 0 || radius > maxNearbyRadiusKm {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid radius")
+			return
+		}
+	}
+
+	// Получаем параметры пагинации
+	limit, offset := utils.PaginationParams(r, 10, 100)
+	page := (offset / limit) + 1
 
-	// Получаем ID пользователя из контекста (если есть)
 	userID, _ := utils.GetUserIDFromContext(r.Context())
 
-	// Создаем фильтр
-	filter := models.RequestFilter{
-		Status:   status,
-		Category: category,
-		UserID:   userID,
-		Limit:    limit,
-		Offset:   offset,
+	filter := models.NearbyRequestFilter{
+		RequestFilter: models.RequestFilter{
+			Status:   query.Get("status"),
+			Category: query.Get("category"),
+			UserID:   userID,
+			Limit:    limit,
+			Offset:   offset,
+		},
+		Lat:      lat,
+		Lon:      lon,
+		RadiusKm: radius,
 	}
 
-	// Получаем заявки
-	requests, totalCount, err := h.requestService.GetRequests(r.Context(), filter)
+	requests, totalCount, err := h.requestService.GetNearbyRequests(filter)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get requests: "+err.Error())
+		respondWithServiceError(w, err, "Failed to get nearby requests")
 		return
 	}
 
-	// Возвращаем результат
 	response := models.PaginatedResponse{
 		Items:      requests,
 		TotalItems: totalCount,
@@ -998,6 +1150,8 @@ func (h *RequestHandler) GetRequests(w http.ResponseWriter, r *http.Request) {
 // @Param status query string false "Статус запроса"
 // @Param page query int false "Номер страницы"
 // @Param limit query int false "Количество элементов на странице"
+// @Param cursor query string false "Курсор следующей страницы (next_cursor из предыдущего ответа)"
+// @Param sort query string false "Порядок: по умолчанию или due_soon - сначала ближайший срок"
 // @Success 200 {object} models.PaginatedResponse
 // @Failure 401 {object} models.ErrorResponse
 // @Failure 403 {object} models.ErrorResponse
@@ -1025,41 +1179,15 @@ func (h *RequestHandler) GetVolunteerRequests(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Получение параметров запроса
-	status := r.URL.Query().Get("status")
-
-	// Получение параметров пагинации с дефолтными значениями
-	page := 1
-	limit := 10
-
-	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
-		if pageVal, err := strconv.Atoi(pageStr); err == nil && pageVal > 0 {
-			page = pageVal
-		}
-	}
-
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if limitVal, err := strconv.Atoi(limitStr); err == nil && limitVal > 0 {
-			limit = limitVal
-		}
-	}
+	filter := requestFilterFromQuery(r)
 
 	// Получение списка запросов волонтера через сервис
-	requests, total, err := h.requestService.GetVolunteerRequests(userID, status, page, limit)
+	list, err := h.requestService.GetVolunteerRequests(userID, filter)
 	if err != nil {
 		h.logger.WithError(err).Error("Failed to get volunteer requests")
-		utils.RespondWithError(w, http.StatusInternalServerError, "Failed to get volunteer requests", err.Error())
+		respondWithServiceError(w, err, "Failed to get volunteer requests")
 		return
 	}
 
-	// Формирование ответа с пагинацией
-	response := models.PaginatedResponse{
-		Data:       requests,
-		TotalItems: total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: (total + limit - 1) / limit,
-	}
-
-	utils.RespondWithJSON(w, http.StatusOK, response)
+	respondWithRequestList(w, list, filter)
 }
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// ReleaseRequest освобождает волонтера от взятой заявки
+// @Summary Отказаться от заявки
+// @Description Снимает волонтера с заявки с обязательной причиной и возвращает заявку в поиск волонтеров. Если указан handoverTo, заявка передается этому волонтеру после его согласия
+// @Tags requests
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param input body models.RequestReleaseInput true "Причина отказа и волонтер для передачи"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/release [post]
+func (h *RequestHandler) ReleaseRequest(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	var input models.RequestReleaseInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	request, err := h.requestService.ReleaseRequest(userID, requestID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to release request")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, request)
+}
+
+// AcceptRequestHandover принимает передачу заявки
+// @Summary Принять передачу заявки
+// @Description Волонтер, которому передают заявку, занимает место передающего волонтера
+// @Tags requests
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/handover/accept [post]
+func (h *RequestHandler) AcceptRequestHandover(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	request, err := h.requestService.AcceptRequestHandover(userID, requestID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to accept request handover")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, request)
+}
+
+// DeclineRequestHandover отклоняет передачу заявки
+// @Summary Отклонить передачу заявки
+// @Description Передающий волонтер остается закрепленным за заявкой и получает уведомление
+// @Tags requests
+// @Param id path int true "ID заявки"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/handover/decline [post]
+func (h *RequestHandler) DeclineRequestHandover(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	if err := h.requestService.DeclineRequestHandover(userID, requestID); err != nil {
+		respondWithServiceError(w, err, "Failed to decline request handover")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// maxImportFileSize - максимальный размер CSV-файла импорта заявок
+const maxImportFileSize = 5 << 20
+
+// ImportRequests импортирует заявки из CSV
+// @Summary Импорт заявок из CSV
+// @Description Принимает CSV в теле запроса (text/csv) или в поле file формы multipart/form-data. Колонки: external_id, title, description, category (название), location - обязательные; priority, location_lat, location_lon, volunteer_slots, due_at (RFC 3339), requester_phone и/или requester_telegram_id. Сначала проверяются все строки: при ошибках заявки не создаются, а отчет содержит ошибки по строкам (статус 422). Строки с уже импортированным external_id пропускаются
+// @Tags requests
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param dry_run query bool false "Только проверить файл, не создавая заявки"
+// @Success 200 {object} models.RequestImportReport
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 413 {object} utils.ErrorResponse
+// @Failure 422 {object} models.RequestImportReport
+// @Security BearerAuth
+// @Router /api/admin/requests/import [post]
+func (h *RequestHandler) ImportRequests(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+
+	var data []byte
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Field file is required")
+			return
+		}
+		defer file.Close()
+		data, err = io.ReadAll(file)
+	} else {
+		data, err = io.ReadAll(r.Body)
+	}
+	if err != nil {
+		utils.RespondWithError(w, http.StatusRequestEntityTooLarge, "File is too large")
+		return
+	}
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	report, err := h.requestService.ImportRequests(userID, data, dryRun)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to import requests")
+		return
+	}
+
+	status := http.StatusOK
+	if report.Invalid > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	utils.RespondWithJSON(w, status, report)
+}
+
+// RegisterRequestImportRoutes регистрирует маршруты импорта заявок.
+// Все маршруты доступны только администраторам.
+func RegisterRequestImportRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Post("/api/admin/requests/import", h.ImportRequests)
+	})
+}
@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetRequestMessages возвращает личную переписку по заявке
+// @Summary Личная переписка по заявке
+// @Description Возвращает последние сообщения переписки (от старых к новым), отметки прочтения участников и число непрочитанных. Доступно автору заявки, ее волонтерам и администраторам
+// @Tags requests
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param before_id query int false "Загрузить сообщения раньше этого (nextBeforeId из предыдущего ответа)"
+// @Param limit query int false "Количество сообщений (по умолчанию 50, не больше 100)"
+// @Success 200 {object} models.RequestMessageThread
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/messages [get]
+func (h *RequestHandler) GetRequestMessages(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	limit, _ := utils.PaginationParams(r, 50, 100)
+	beforeID := 0
+	if value := r.URL.Query().Get("before_id"); value != "" {
+		beforeID, err = strconv.Atoi(value)
+		if err != nil || beforeID < 0 {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid before_id")
+			return
+		}
+	}
+
+	thread, err := h.requestService.GetRequestMessages(userID, requestID, beforeID, limit)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get messages")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, thread)
+}
+
+// SendRequestMessage отправляет личное сообщение по заявке
+// @Summary Отправка личного сообщения
+// @Description Отправляет сообщение в переписку по заявке. Остальные участники получают уведомление. После завершения или отмены заявки переписка доступна только для чтения (409)
+// @Tags requests
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param message body models.RequestMessageInput true "Текст сообщения"
+// @Success 201 {object} models.RequestMessage
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/messages [post]
+func (h *RequestHandler) SendRequestMessage(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	var input models.RequestMessageInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	message, err := h.requestService.SendRequestMessage(userID, requestID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to send message")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, message)
+}
+
+// MarkRequestMessagesRead отмечает переписку прочитанной
+// @Summary Отметка прочтения переписки
+// @Description Отмечает переписку прочитанной до сообщения messageId включительно; без messageId - до последнего сообщения
+// @Tags requests
+// @Accept json
+// @Param id path int true "ID заявки"
+// @Param read body models.RequestMessageReadInput false "Последнее прочитанное сообщение"
+// @Success 204
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/messages/read [post]
+func (h *RequestHandler) MarkRequestMessagesRead(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	var input models.RequestMessageReadInput
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+
+	if err := h.requestService.MarkRequestMessagesRead(userID, requestID, input.MessageID); err != nil {
+		respondWithServiceError(w, err, "Failed to mark messages read")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUnreadMessageCounts возвращает непрочитанные личные сообщения текущего пользователя
+// @Summary Непрочитанные личные сообщения
+// @Description Заявки, в переписке которых у текущего пользователя есть непрочитанные сообщения, и их число
+// @Tags requests
+// @Produce json
+// @Success 200 {array} models.RequestUnreadMessages
+// @Failure 401 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/me/messages/unread [get]
+func (h *RequestHandler) GetUnreadMessageCounts(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	counts, err := h.requestService.GetUnreadMessageCounts(userID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get unread messages")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, counts)
+}
+
+// RegisterRequestMessageRoutes регистрирует маршруты личной переписки по заявкам.
+// Маршруты требуют авторизации; права проверяются в сервисе.
+func RegisterRequestMessageRoutes(r chi.Router, h *RequestHandler) {
+	r.Get("/api/requests/{id}/messages", h.GetRequestMessages)
+	r.Post("/api/requests/{id}/messages", h.SendRequestMessage)
+	r.Post("/api/requests/{id}/messages/read", h.MarkRequestMessagesRead)
+	r.Get("/api/users/me/messages/unread", h.GetUnreadMessageCounts)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetModerationQueue возвращает заявки, ожидающие проверки
+// @Summary Очередь проверки заявок
+// @Description Заявки новых и отмеченных авторов, ожидающие решения администратора, самые давние первыми
+// @Tags moderation
+// @Produce json
+// @Param page query int false "Номер страницы"
+// @Param limit query int false "Количество заявок на странице"
+// @Success 200 {object} models.PaginatedResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/requests [get]
+func (h *RequestHandler) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	limit, offset := utils.PaginationParams(r, 20, 100)
+	page := 1
+	if offset > 0 {
+		page = (offset / limit) + 1
+	}
+
+	requests, totalCount, err := h.requestService.GetModerationQueue(limit, offset)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get moderation queue")
+		return
+	}
+
+	response := models.PaginatedResponse{
+		Items:      requests,
+		TotalItems: totalCount,
+		TotalPages: (totalCount + limit - 1) / limit,
+		Page:       page,
+		PageSize:   limit,
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+// ApproveRequest одобряет заявку на проверке
+// @Summary Одобрить заявку
+// @Description Заявка на проверке публикуется и становится видна волонтерам. Автор получает уведомление
+// @Tags moderation
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/approve [post]
+func (h *RequestHandler) ApproveRequest(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	request, err := h.requestService.ApproveRequest(userID, requestID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to approve request")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, request)
+}
+
+// RejectRequest отклоняет заявку на проверке
+// @Summary Отклонить заявку
+// @Description Заявка на проверке отклоняется с обязательной причиной. Автор получает уведомление с причиной
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param input body models.ModerationDecisionInput true "Причина"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/reject [post]
+func (h *RequestHandler) RejectRequest(w http.ResponseWriter, r *http.Request) {
+	h.decideModeration(w, r, h.requestService.RejectRequest, "Failed to reject request")
+}
+
+// RequestChanges возвращает заявку автору на доработку
+// @Summary Вернуть заявку на доработку
+// @Description Заявка на проверке возвращается автору с обязательной причиной. После изменения заявка снова попадает в очередь проверки
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Param input body models.ModerationDecisionInput true "Причина"
+// @Success 200 {object} models.RequestFullInfo
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/request-changes [post]
+func (h *RequestHandler) RequestChanges(w http.ResponseWriter, r *http.Request) {
+	h.decideModeration(w, r, h.requestService.RequestChanges, "Failed to request changes")
+}
+
+// decideModeration разбирает решение администратора с причиной и применяет его к заявке
+func (h *RequestHandler) decideModeration(
+	w http.ResponseWriter,
+	r *http.Request,
+	decide func(adminID, requestID int, input models.ModerationDecisionInput) (models.RequestFullInfo, error),
+	failureMessage string,
+) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	var input models.ModerationDecisionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	request, err := decide(userID, requestID, input)
+	if err != nil {
+		respondWithServiceError(w, err, failureMessage)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, request)
+}
+
+// SetUserRequiresReview отмечает пользователя, все заявки которого проходят проверку
+// @Summary Проверка заявок пользователя
+// @Description Отмеченный пользователь публикует заявки только после проверки, независимо от истории. Снятие отметки возвращает обычные правила
+// @Tags moderation
+// @Accept json
+// @Param id path int true "ID пользователя"
+// @Param input body models.UserReviewFlagInput true "Отметка"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/moderation/users/{id} [put]
+func (h *RequestHandler) SetUserRequiresReview(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var input models.UserReviewFlagInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.requestService.SetUserRequiresReview(userID, input); err != nil {
+		respondWithServiceError(w, err, "Failed to update user review flag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegisterRequestModerationRoutes регистрирует маршруты проверки заявок.
+// Все маршруты доступны только администраторам.
+func RegisterRequestModerationRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Get("/api/moderation/requests", h.GetModerationQueue)
+		r.Post("/api/requests/{id}/approve", h.ApproveRequest)
+		r.Post("/api/requests/{id}/reject", h.RejectRequest)
+		r.Post("/api/requests/{id}/request-changes", h.RequestChanges)
+		r.Put("/api/moderation/users/{id}", h.SetUserRequiresReview)
+	})
+}
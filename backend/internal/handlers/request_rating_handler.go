@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetUserReviews возвращает отзывы, полученные пользователем
+// @Summary Отзывы о пользователе
+// @Description Рейтинг пользователя и полученные им оценки от авторов заявок и волонтеров, новые первыми. Рейтинг - байесовское среднее: к оценкам пользователя добавляется RATING_PRIOR_WEIGHT оценок, равных RATING_PRIOR_MEAN, поэтому при малом числе оценок он близок к RATING_PRIOR_MEAN
+// @Tags users
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Param limit query int false "Лимит записей" default(10)
+// @Param offset query int false "Смещение" default(0)
+// @Success 200 {object} models.UserReviews
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{id}/reviews [get]
+func (h *RequestHandler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	limit, offset := utils.PaginationParams(r, 10, 100)
+
+	reviews, err := h.requestService.GetUserReviews(userID, limit, offset)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get user reviews")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, reviews)
+}
+
+// RegisterRequestRatingRoutes регистрирует маршруты отзывов о пользователях.
+// Маршруты требуют авторизации.
+func RegisterRequestRatingRoutes(r chi.Router, h *RequestHandler) {
+	r.Get("/api/users/{id}/reviews", h.GetUserReviews)
+}
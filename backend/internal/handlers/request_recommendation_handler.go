@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetRecommendedRequests возвращает открытые заявки, подобранные для текущего волонтера
+// @Summary Рекомендованные заявки
+// @Description Оценивает открытые заявки по опыту волонтера в категории, расстоянию от дома, его рейтингу, времени ожидания и приоритету заявки. Для каждой заявки возвращаются главные причины оценки
+// @Tags requests
+// @Produce json
+// @Param limit query int false "Количество заявок (по умолчанию 10, максимум 50)"
+// @Success 200 {array} models.RecommendedRequest
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/recommended [get]
+func (h *RequestHandler) GetRecommendedRequests(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, _ := utils.PaginationParams(r, 10, 50)
+
+	recommended, err := h.requestService.GetRecommendedRequests(userID, limit)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get recommended requests")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, recommended)
+}
+
+// SetHomeLocation сохраняет домашнюю точку текущего пользователя
+// @Summary Домашняя точка
+// @Description Заявки рядом с домашней точкой выше в рекомендациях. Пустые координаты удаляют точку
+// @Tags users
+// @Accept json
+// @Param input body models.HomeLocationInput true "Координаты"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/me/home-location [put]
+func (h *RequestHandler) SetHomeLocation(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var input models.HomeLocationInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	if err := h.requestService.SetHomeLocation(userID, input); err != nil {
+		respondWithServiceError(w, err, "Failed to set home location")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetRequestReport возвращает операционный отчет по заявкам
+// @Summary Операционный отчет по заявкам
+// @Description Медиана и 90-й перцентиль времени от создания до назначения и от назначения до выполнения (в минутах), доли отмененных и истекших заявок по группам. Учитываются заявки, созданные в периоде; по умолчанию - последние три месяца по неделям
+// @Tags requests
+// @Produce json
+// @Param from query string false "Начало периода (YYYY-MM-DD)"
+// @Param to query string false "Конец периода включительно (YYYY-MM-DD)"
+// @Param group_by query string false "Разрез: category, priority, week (по умолчанию) или month"
+// @Success 200 {object} models.RequestReport
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/reports/requests [get]
+func (h *RequestHandler) GetRequestReport(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	from, to, err := periodFromQuery(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid period: "+err.Error())
+		return
+	}
+
+	if to.IsZero() {
+		now := time.Now()
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, -3, 0)
+	}
+
+	groupBy := models.RequestReportGroupBy(r.URL.Query().Get("group_by"))
+	if groupBy == "" {
+		groupBy = models.RequestReportByWeek
+	}
+
+	report, err := h.requestService.GetRequestReport(userID, models.RequestReportFilter{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get request report")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, report)
+}
+
+// RegisterRequestReportRoutes регистрирует маршруты отчетов по заявкам.
+// Все маршруты доступны только администраторам.
+func RegisterRequestReportRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Get("/api/reports/requests", h.GetRequestReport)
+	})
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetMyRequestSeries возвращает серии повторяющихся заявок текущего пользователя
+// @Summary Мои серии заявок
+// @Description Возвращает серии, созданные пользователем, и серии, где он волонтер по умолчанию
+// @Tags request-series
+// @Produce json
+// @Success 200 {array} models.RequestSeries
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series [get]
+func (h *RequestHandler) GetMyRequestSeries(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	series, err := h.requestService.GetUserRequestSeries(userID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get request series")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, series)
+}
+
+// GetRequestSeries возвращает серию повторяющихся заявок
+// @Summary Получить серию заявок
+// @Tags request-series
+// @Produce json
+// @Param id path int true "ID серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id} [get]
+func (h *RequestHandler) GetRequestSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid series ID")
+		return
+	}
+
+	series, err := h.requestService.GetRequestSeries(seriesID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get request series")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, series)
+}
+
+// UpdateRequestSeries изменяет серию повторяющихся заявок
+// @Summary Изменить серию заявок
+// @Description Изменяет данные и правило повторения серии. Уже созданные повторения не меняются
+// @Tags request-series
+// @Accept json
+// @Produce json
+// @Param id path int true "ID серии"
+// @Param input body models.RequestSeriesUpdateInput true "Изменения серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Failure 500 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id} [put]
+func (h *RequestHandler) UpdateRequestSeries(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	seriesID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid series ID")
+		return
+	}
+
+	var input models.RequestSeriesUpdateInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	series, err := h.requestService.UpdateRequestSeries(userID, seriesID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to update request series")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, series)
+}
+
+// PauseRequestSeries приостанавливает серию
+// @Summary Приостановить серию заявок
+// @Tags request-series
+// @Produce json
+// @Param id path int true "ID серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id}/pause [post]
+func (h *RequestHandler) PauseRequestSeries(w http.ResponseWriter, r *http.Request) {
+	h.handleRequestSeriesAction(w, r, h.requestService.PauseRequestSeries, "Failed to pause request series")
+}
+
+// ResumeRequestSeries возобновляет приостановленную серию
+// @Summary Возобновить серию заявок
+// @Description Повторения, пропущенные за время паузы, не создаются
+// @Tags request-series
+// @Produce json
+// @Param id path int true "ID серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id}/resume [post]
+func (h *RequestHandler) ResumeRequestSeries(w http.ResponseWriter, r *http.Request) {
+	h.handleRequestSeriesAction(w, r, h.requestService.ResumeRequestSeries, "Failed to resume request series")
+}
+
+// EndRequestSeries завершает серию
+// @Summary Завершить серию заявок
+// @Description Новые повторения больше не создаются, уже созданные остаются
+// @Tags request-series
+// @Produce json
+// @Param id path int true "ID серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id}/end [post]
+func (h *RequestHandler) EndRequestSeries(w http.ResponseWriter, r *http.Request) {
+	h.handleRequestSeriesAction(w, r, h.requestService.EndRequestSeries, "Failed to end request series")
+}
+
+// JoinRequestSeries назначает текущего волонтера помощником по умолчанию
+// @Summary Стать волонтером серии
+// @Description Волонтер будет закреплен за каждым новым повторением серии
+// @Tags request-series
+// @Produce json
+// @Param id path int true "ID серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id}/volunteer [post]
+func (h *RequestHandler) JoinRequestSeries(w http.ResponseWriter, r *http.Request) {
+	h.handleRequestSeriesAction(w, r, h.requestService.JoinRequestSeries, "Failed to join request series")
+}
+
+// LeaveRequestSeries снимает волонтера по умолчанию с серии
+// @Summary Отказаться от серии
+// @Tags request-series
+// @Produce json
+// @Param id path int true "ID серии"
+// @Success 200 {object} models.RequestSeries
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/request-series/{id}/volunteer [delete]
+func (h *RequestHandler) LeaveRequestSeries(w http.ResponseWriter, r *http.Request) {
+	h.handleRequestSeriesAction(w, r, h.requestService.LeaveRequestSeries, "Failed to leave request series")
+}
+
+// handleRequestSeriesAction выполняет действие пользователя над серией без тела запроса
+func (h *RequestHandler) handleRequestSeriesAction(w http.ResponseWriter, r *http.Request,
+	action func(userID, seriesID int) (*models.RequestSeries, error), failureMessage string) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	seriesID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid series ID")
+		return
+	}
+
+	series, err := action(userID, seriesID)
+	if err != nil {
+		respondWithServiceError(w, err, failureMessage)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, series)
+}
+
+// RegisterRequestSeriesRoutes регистрирует маршруты серий повторяющихся заявок
+func RegisterRequestSeriesRoutes(r chi.Router, h *RequestHandler) {
+	r.Route("/api/request-series", func(r chi.Router) {
+		r.Get("/", h.GetMyRequestSeries)
+		r.Get("/{id}", h.GetRequestSeries)
+		r.Put("/{id}", h.UpdateRequestSeries)
+		r.Post("/{id}/pause", h.PauseRequestSeries)
+		r.Post("/{id}/resume", h.ResumeRequestSeries)
+		r.Post("/{id}/end", h.EndRequestSeries)
+		r.Post("/{id}/volunteer", h.JoinRequestSeries)
+		r.Delete("/{id}/volunteer", h.LeaveRequestSeries)
+	})
+}
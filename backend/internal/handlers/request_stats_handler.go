@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// GetStatsTimeseries возвращает временной ряд статистики для главной страницы
+// @Summary Временной ряд статистики
+// @Description Показатель по дням, неделям или месяцам из дневных сводок, которые пересчитывает фоновая задача. По умолчанию - последние 30 дней, 12 недель или 12 месяцев в зависимости от шага
+// @Tags requests
+// @Produce json
+// @Param metric query string true "Показатель: created_requests, completed_requests, active_volunteers или new_users"
+// @Param interval query string false "Шаг: day (по умолчанию), week или month"
+// @Param from query string false "Начало периода (YYYY-MM-DD)"
+// @Param to query string false "Конец периода включительно (YYYY-MM-DD)"
+// @Success 200 {object} models.StatsTimeseries
+// @Failure 400 {object} utils.ErrorResponse
+// @Router /api/stats/timeseries [get]
+func (h *RequestHandler) GetStatsTimeseries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from, to, err := periodFromQuery(r)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid period: "+err.Error())
+		return
+	}
+
+	interval := models.StatsInterval(query.Get("interval"))
+	if interval == "" {
+		interval = models.StatsIntervalDay
+	}
+
+	if to.IsZero() {
+		now := time.Now()
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	}
+	if from.IsZero() {
+		switch interval {
+		case models.StatsIntervalWeek:
+			from = to.AddDate(0, 0, -7*12)
+		case models.StatsIntervalMonth:
+			from = to.AddDate(0, -12, 0)
+		default:
+			from = to.AddDate(0, 0, -30)
+		}
+	}
+
+	timeseries, err := h.requestService.GetStatsTimeseries(models.StatsTimeseriesFilter{
+		Metric:   models.StatsMetric(query.Get("metric")),
+		Interval: interval,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get stats timeseries")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, timeseries)
+}
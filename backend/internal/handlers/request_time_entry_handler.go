@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// CheckIn отмечает начало работы волонтера над заявкой
+// @Summary Начать работу над заявкой
+// @Description Открывает отметку времени закрепленного волонтера
+// @Tags time-entries
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 201 {object} models.TimeEntry
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/checkin [post]
+func (h *RequestHandler) CheckIn(w http.ResponseWriter, r *http.Request) {
+	h.handleTimeEntryAction(w, r, h.requestService.CheckIn, http.StatusCreated, "Failed to check in")
+}
+
+// CheckOut отмечает окончание работы волонтера над заявкой
+// @Summary Закончить работу над заявкой
+// @Description Закрывает отметку времени; за одну отметку засчитывается не больше 12 часов
+// @Tags time-entries
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 200 {object} models.TimeEntry
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/checkout [post]
+func (h *RequestHandler) CheckOut(w http.ResponseWriter, r *http.Request) {
+	h.handleTimeEntryAction(w, r, h.requestService.CheckOut, http.StatusOK, "Failed to check out")
+}
+
+// GetRequestTimeEntries возвращает отметки времени по заявке
+// @Summary Отметки времени заявки
+// @Description Доступно автору заявки, ее волонтерам и администраторам
+// @Tags time-entries
+// @Produce json
+// @Param id path int true "ID заявки"
+// @Success 200 {array} models.TimeEntry
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/requests/{id}/time-entries [get]
+func (h *RequestHandler) GetRequestTimeEntries(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	entries, err := h.requestService.GetRequestTimeEntries(userID, requestID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get time entries")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, entries)
+}
+
+// CorrectTimeEntry исправляет отметку времени
+// @Summary Исправить отметку времени
+// @Description Администратор задает время начала и окончания с указанием причины. Часы волонтера завершенной заявки пересчитываются
+// @Tags time-entries
+// @Accept json
+// @Produce json
+// @Param id path int true "ID отметки"
+// @Param input body models.TimeEntryCorrectionInput true "Исправление"
+// @Success 200 {object} models.TimeEntry
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Failure 409 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/time-entries/{id} [put]
+func (h *RequestHandler) CorrectTimeEntry(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	entryID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid time entry ID")
+		return
+	}
+
+	var input models.TimeEntryCorrectionInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+
+	entry, err := h.requestService.CorrectTimeEntry(userID, entryID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to correct time entry")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, entry)
+}
+
+// handleTimeEntryAction выполняет отметку времени волонтера по заявке без тела запроса
+func (h *RequestHandler) handleTimeEntryAction(w http.ResponseWriter, r *http.Request,
+	action func(userID, requestID int) (*models.TimeEntry, error), status int, failureMessage string) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	requestID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid request ID")
+		return
+	}
+
+	entry, err := action(userID, requestID)
+	if err != nil {
+		respondWithServiceError(w, err, failureMessage)
+		return
+	}
+
+	utils.RespondWithJSON(w, status, entry)
+}
+
+// RegisterTimeEntryRoutes регистрирует маршруты исправления отметок времени для администраторов
+func RegisterTimeEntryRoutes(r chi.Router, h *RequestHandler) {
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Put("/api/time-entries/{id}", h.CorrectTimeEntry)
+	})
+}
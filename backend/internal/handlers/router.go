@@ -15,6 +15,8 @@ func SetupRouter(
 	userHandler *UserHandler,
 	gameHandler *GameHandler,
 	requestHandler *RequestHandler,
+	partnerHandler *PartnerHandler,
+	eventHandler *EventHandler,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
@@ -28,7 +30,7 @@ func SetupRouter(
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Last-Event-ID"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: true,
 		MaxAge:           300,
@@ -43,6 +45,9 @@ func SetupRouter(
 		w.Write([]byte("OK"))
 	})
 
+	// Поток событий проверяет токен сам: он принимается и в параметре ?token=
+	RegisterEventStreamRoutes(r, eventHandler)
+
 	// Публичные маршруты
 	r.Group(func(r chi.Router) {
 		// Аутентификация
@@ -54,7 +59,12 @@ func SetupRouter(
 
 		// Публичная статистика
 		r.Get("/api/stats", requestHandler.GetRequestStats)
+		r.Get("/api/stats/timeseries", requestHandler.GetStatsTimeseries)
 		r.Get("/api/requests/categories", requestHandler.GetRequestCategories)
+
+		// Партнерские организации
+		r.Get("/api/partners", partnerHandler.GetPartners)
+		r.Get("/api/partners/{id}", partnerHandler.GetPartner)
 	})
 
 	// Защищенные маршруты (требуют авторизации)
@@ -73,6 +83,24 @@ func SetupRouter(
 
 		// Заявки
 		RegisterRequestRoutes(r, requestHandler)
+		RegisterRequestSeriesRoutes(r, requestHandler)
+		RegisterRequestEscalationRoutes(r, requestHandler)
+		RegisterTimeEntryRoutes(r, requestHandler)
+		RegisterRequestDispatchRoutes(r, requestHandler)
+		RegisterRequestModerationRoutes(r, requestHandler)
+		RegisterRequestImportRoutes(r, requestHandler)
+		RegisterRequestExportRoutes(r, requestHandler)
+		RegisterRequestReportRoutes(r, requestHandler)
+		RegisterRequestMessageRoutes(r, requestHandler)
+		RegisterRequestRatingRoutes(r, requestHandler)
+		RegisterAbuseReportRoutes(r, requestHandler)
+		RegisterUserBlockRoutes(r, requestHandler)
+
+		// Партнерские организации
+		RegisterPartnerRoutes(r, partnerHandler)
+
+		// Токен потока событий
+		RegisterEventRoutes(r, eventHandler)
 
 		// Игровые функции
 		RegisterGameRoutes(r, gameHandler)
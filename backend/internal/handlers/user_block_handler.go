@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kal9mov/moshosp/backend/internal/domain/models"
+	"github.com/kal9mov/moshosp/backend/internal/middleware"
+	"github.com/kal9mov/moshosp/backend/internal/utils"
+)
+
+// BlockUser добавляет пользователя в черный список
+// @Summary Заблокировать пользователя
+// @Description Заблокированный пользователь не может брать заявки автора, не видит их в рекомендациях, не может комментировать их, отвечать на комментарии автора и писать в переписку, где автор участвует. Повторная блокировка обновляет причину. Администраторов заблокировать нельзя
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Param input body models.UserBlockInput false "Причина блокировки"
+// @Success 200 {object} models.UserBlock
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{id}/block [post]
+func (h *RequestHandler) BlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	targetID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var input models.UserBlockInput
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+
+	block, err := h.requestService.BlockUser(userID, targetID, input)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to block user")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, block)
+}
+
+// UnblockUser убирает пользователя из черного списка
+// @Summary Разблокировать пользователя
+// @Tags users
+// @Param id path int true "ID пользователя"
+// @Success 204 "No Content"
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 401 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/{id}/block [delete]
+func (h *RequestHandler) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	targetID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.requestService.UnblockUser(userID, targetID); err != nil {
+		respondWithServiceError(w, err, "Failed to unblock user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUserBlocks возвращает черный список текущего пользователя
+// @Summary Мой черный список
+// @Tags users
+// @Produce json
+// @Success 200 {array} models.UserBlock
+// @Failure 401 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/users/me/blocks [get]
+func (h *RequestHandler) GetUserBlocks(w http.ResponseWriter, r *http.Request) {
+	userID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	blocks, err := h.requestService.GetUserBlocks(userID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get blocked users")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, blocks)
+}
+
+// GetUserBlockRelations возвращает черные списки, связанные с пользователем
+// @Summary Черные списки пользователя
+// @Description blocking - кого заблокировал пользователь, blockedBy - кто заблокировал пользователя. Помогает при разборе жалоб
+// @Tags users
+// @Produce json
+// @Param id path int true "ID пользователя"
+// @Success 200 {object} models.UserBlockRelations
+// @Failure 400 {object} utils.ErrorResponse
+// @Failure 403 {object} utils.ErrorResponse
+// @Failure 404 {object} utils.ErrorResponse
+// @Security BearerAuth
+// @Router /api/admin/users/{id}/blocks [get]
+func (h *RequestHandler) GetUserBlockRelations(w http.ResponseWriter, r *http.Request) {
+	adminID, err := utils.GetUserIDFromContext(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	relations, err := h.requestService.GetUserBlockRelations(adminID, userID)
+	if err != nil {
+		respondWithServiceError(w, err, "Failed to get user blocks")
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, relations)
+}
+
+// RegisterUserBlockRoutes регистрирует маршруты черного списка.
+// Просмотр черных списков другого пользователя доступен только администраторам.
+func RegisterUserBlockRoutes(r chi.Router, h *RequestHandler) {
+	r.Get("/api/users/me/blocks", h.GetUserBlocks)
+	r.Post("/api/users/{id}/block", h.BlockUser)
+	r.Delete("/api/users/{id}/block", h.UnblockUser)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AdminOnly)
+		r.Get("/api/admin/users/{id}/blocks", h.GetUserBlockRelations)
+	})
+}
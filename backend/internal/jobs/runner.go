@@ -0,0 +1,105 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Func - периодическая фоновая задача
+type Func func(ctx context.Context) error
+
+// job описывает зарегистрированную задачу
+type job struct {
+	name     string
+	interval time.Duration
+	timeout  time.Duration
+	run      Func
+}
+
+// Runner запускает фоновые задачи с заданным интервалом.
+// Каждая задача выполняется в своей горутине, повторные запуски одной задачи не пересекаются.
+type Runner struct {
+	logger *slog.Logger
+	jobs   []job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRunner создает новый планировщик фоновых задач
+func NewRunner(logger *slog.Logger) *Runner {
+	return &Runner{logger: logger}
+}
+
+// Add регистрирует задачу. Задача выполняется сразу после запуска планировщика,
+// затем каждые interval; одно выполнение ограничено timeout.
+// Задачи нужно регистрировать до вызова Start.
+func (r *Runner) Add(name string, interval, timeout time.Duration, run Func) {
+	r.jobs = append(r.jobs, job{
+		name:     name,
+		interval: interval,
+		timeout:  timeout,
+		run:      run,
+	})
+}
+
+// Start запускает все зарегистрированные задачи
+func (r *Runner) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	for _, j := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(ctx, j)
+	}
+}
+
+// Stop останавливает задачи и ждет завершения текущих выполнений
+func (r *Runner) Stop() {
+	if r.cancel == nil {
+		return
+	}
+
+	r.cancel()
+	r.wg.Wait()
+}
+
+// loop выполняет задачу по таймеру до остановки планировщика
+func (r *Runner) loop(ctx context.Context, j job) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		r.runOnce(ctx, j)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce выполняет задачу один раз, перехватывая панику, чтобы не остановить сервер
+func (r *Runner) runOnce(ctx context.Context, j job) {
+	ctx, cancel := context.WithTimeout(ctx, j.timeout)
+	defer cancel()
+
+	defer func() {
+		if p := recover(); p != nil {
+			r.logger.Error("Паника в фоновой задаче", "job", j.name, "panic", p)
+		}
+	}()
+
+	start := time.Now()
+	if err := j.run(ctx); err != nil && ctx.Err() == nil {
+		r.logger.Error("Ошибка фоновой задачи", "job", j.name, "error", err)
+		return
+	}
+
+	r.logger.Debug("Фоновая задача выполнена", "job", j.name, "duration", time.Since(start))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,6 +18,12 @@ const (
 	UserRoleKey = "user_role"
 )
 
+// eventStreamScope - назначение токена потока событий в claim "scope"
+const eventStreamScope = "events"
+
+// EventStreamTokenTTL - время жизни токена потока событий
+const EventStreamTokenTTL = time.Minute
+
 // JWTAuth - middleware для проверки JWT токенов
 func JWTAuth(secret string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -35,29 +42,93 @@ func JWTAuth(secret string) func(http.Handler) http.Handler {
 				return
 			}
 
-			// Получаем идентификатор пользователя из токена
-			userID, err := claims.GetSubject()
-			if err != nil {
+			// Токен потока событий годится только для потока событий
+			if _, scoped := claims["scope"]; scoped {
 				http.Error(w, "Invalid token", http.StatusUnauthorized)
 				return
 			}
 
-			// Получаем роль пользователя из токена (если есть)
-			role, ok := claims["role"].(string)
-			if !ok {
-				role = "user" // По умолчанию - обычный пользователь
+			serveWithClaims(w, r, next, claims)
+		})
+	}
+}
+
+// EventStreamAuth - middleware потока событий. Браузерный EventSource не передает заголовок
+// Authorization, поэтому кроме обычного токена в заголовке принимается токен
+// из GenerateEventStreamToken в параметре ?token=
+func EventStreamAuth(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenString := extractTokenFromHeader(r)
+			fromQuery := false
+			if tokenString == "" {
+				tokenString = r.URL.Query().Get("token")
+				fromQuery = true
+			}
+			if tokenString == "" {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := validateToken(tokenString, secret)
+			if err != nil {
+				http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+				return
 			}
 
-			// Создаем новый контекст с данными пользователя
-			ctx := context.WithValue(r.Context(), UserIDKey, userID)
-			ctx = context.WithValue(ctx, UserRoleKey, role)
+			// Адрес с токеном попадает в журналы прокси, поэтому в параметре
+			// принимается только короткоживущий токен потока событий
+			if scope, _ := claims["scope"].(string); fromQuery && scope != eventStreamScope {
+				http.Error(w, "Invalid token", http.StatusUnauthorized)
+				return
+			}
 
-			// Передаем управление следующему обработчику с обновленным контекстом
-			next.ServeHTTP(w, r.WithContext(ctx))
+			serveWithClaims(w, r, next, claims)
 		})
 	}
 }
 
+// GenerateEventStreamToken выпускает токен потока событий пользователя userID,
+// действующий EventStreamTokenTTL
+func GenerateEventStreamToken(secret string, userID int) (string, time.Time, error) {
+	expiresAt := time.Now().Add(EventStreamTokenTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   strconv.Itoa(userID),
+		"scope": eventStreamScope,
+		"exp":   expiresAt.Unix(),
+	})
+
+	tokenString, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// serveWithClaims добавляет в контекст данные пользователя из токена и передает запрос дальше
+func serveWithClaims(w http.ResponseWriter, r *http.Request, next http.Handler, claims jwt.MapClaims) {
+	// Получаем идентификатор пользователя из токена
+	userID, err := claims.GetSubject()
+	if err != nil {
+		http.Error(w, "Invalid token", http.StatusUnauthorized)
+		return
+	}
+
+	// Получаем роль пользователя из токена (если есть)
+	role, ok := claims["role"].(string)
+	if !ok {
+		role = "user" // По умолчанию - обычный пользователь
+	}
+
+	// Создаем новый контекст с данными пользователя
+	ctx := context.WithValue(r.Context(), UserIDKey, userID)
+	ctx = context.WithValue(ctx, UserRoleKey, role)
+
+	// Передаем управление следующему обработчику с обновленным контекстом
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // extractTokenFromHeader извлекает JWT токен из заголовка Authorization
 func extractTokenFromHeader(r *http.Request) string {
 	// Получаем Authorization header
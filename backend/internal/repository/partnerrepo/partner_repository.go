@@ -0,0 +1,211 @@
+package partnerrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+// Ошибки репозитория партнерских организаций
+var (
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// partnerColumns - поля партнерской организации с числом сотрудников.
+// Алиас таблицы организаций - p.
+const partnerColumns = `
+	p.id, p.name, COALESCE(p.description, '') as description, COALESCE(p.logo_url, '') as logo_url,
+	COALESCE(p.website_url, '') as website_url, COALESCE(p.contact_email, '') as contact_email,
+	COALESCE(p.contact_phone, '') as contact_phone, COALESCE(p.address, '') as address,
+	p.is_verified, p.created_at, p.updated_at,
+	(SELECT COUNT(*) FROM partner_members m WHERE m.partner_id = p.id) as members_count`
+
+// PartnerRepository представляет репозиторий для работы с партнерскими организациями
+type PartnerRepository struct {
+	db     *sqlx.DB
+	logger *logrus.Logger
+}
+
+// NewPartnerRepository создает новый экземпляр репозитория партнерских организаций
+func NewPartnerRepository(db *sqlx.DB, logger *logrus.Logger) *PartnerRepository {
+	return &PartnerRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPartners получает партнерские организации по названию; verifiedOnly оставляет только проверенные
+func (r *PartnerRepository) GetPartners(ctx context.Context, verifiedOnly bool) ([]models.PartnerOrganization, error) {
+	query := `SELECT ` + partnerColumns + `
+		FROM partner_organizations p
+		WHERE $1 = false OR p.is_verified
+		ORDER BY p.name, p.id
+	`
+
+	var partners []models.PartnerOrganization
+	if err := r.db.SelectContext(ctx, &partners, query, verifiedOnly); err != nil {
+		return nil, fmt.Errorf("failed to get partners: %w", err)
+	}
+
+	return partners, nil
+}
+
+// GetPartnerByID получает партнерскую организацию по ID
+func (r *PartnerRepository) GetPartnerByID(ctx context.Context, id int) (*models.PartnerOrganization, error) {
+	query := `SELECT ` + partnerColumns + `
+		FROM partner_organizations p
+		WHERE p.id = $1
+	`
+
+	var partner models.PartnerOrganization
+	if err := r.db.GetContext(ctx, &partner, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get partner by ID: %w", err)
+	}
+
+	return &partner, nil
+}
+
+// CreatePartner создает партнерскую организацию; новая организация не проверена
+func (r *PartnerRepository) CreatePartner(ctx context.Context, input *models.PartnerInput) (*models.PartnerOrganization, error) {
+	var id int
+	err := r.db.GetContext(ctx, &id, `
+		INSERT INTO partner_organizations (name, description, logo_url, website_url, contact_email, contact_phone, address)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, input.Name, input.Description, input.LogoURL, input.WebsiteURL, input.ContactEmail, input.ContactPhone, input.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create partner: %w", err)
+	}
+
+	return r.GetPartnerByID(ctx, id)
+}
+
+// UpdatePartner заменяет профиль партнерской организации
+func (r *PartnerRepository) UpdatePartner(ctx context.Context, id int, input *models.PartnerInput) (*models.PartnerOrganization, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE partner_organizations
+		SET name = $2, description = $3, logo_url = $4, website_url = $5,
+			contact_email = $6, contact_phone = $7, address = $8
+		WHERE id = $1
+	`, id, input.Name, input.Description, input.LogoURL, input.WebsiteURL, input.ContactEmail, input.ContactPhone, input.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update partner: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	return r.GetPartnerByID(ctx, id)
+}
+
+// SetPartnerVerified отмечает организацию проверенной или снимает отметку
+func (r *PartnerRepository) SetPartnerVerified(ctx context.Context, id int, verified bool) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE partner_organizations SET is_verified = $2 WHERE id = $1`, id, verified)
+	if err != nil {
+		return fmt.Errorf("failed to set partner verification: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// DeletePartner удаляет партнерскую организацию вместе с составом сотрудников.
+// Заявки организации остаются, но теряют связь с ней.
+func (r *PartnerRepository) DeletePartner(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM partner_organizations WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete partner: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetPartnerMembers получает сотрудников организации: сначала руководители, затем по дате добавления
+func (r *PartnerRepository) GetPartnerMembers(ctx context.Context, partnerID int) ([]models.PartnerMember, error) {
+	query := `
+		SELECT
+			m.partner_id, m.user_id, m.role, m.created_at,
+			u.username, COALESCE(u.first_name, '') as first_name,
+			COALESCE(u.last_name, '') as last_name, COALESCE(u.photo_url, '') as photo_url
+		FROM partner_members m
+		INNER JOIN users u ON m.user_id = u.id
+		WHERE m.partner_id = $1
+		ORDER BY m.role, m.created_at, m.user_id
+	`
+
+	var members []models.PartnerMember
+	if err := r.db.SelectContext(ctx, &members, query, partnerID); err != nil {
+		return nil, fmt.Errorf("failed to get partner members: %w", err)
+	}
+
+	return members, nil
+}
+
+// GetMemberRole получает роль пользователя в организации.
+// Если пользователь не сотрудник организации, возвращается repository.ErrNotFound.
+func (r *PartnerRepository) GetMemberRole(ctx context.Context, partnerID, userID int) (models.PartnerMemberRole, error) {
+	var role models.PartnerMemberRole
+	err := r.db.GetContext(ctx, &role, `
+		SELECT role FROM partner_members WHERE partner_id = $1 AND user_id = $2
+	`, partnerID, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", repository.ErrNotFound
+		}
+		return "", fmt.Errorf("failed to get partner member role: %w", err)
+	}
+
+	return role, nil
+}
+
+// SavePartnerMember добавляет сотрудника в организацию или меняет его роль
+func (r *PartnerRepository) SavePartnerMember(ctx context.Context, partnerID, userID int, role models.PartnerMemberRole) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO partner_members (partner_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (partner_id, user_id) DO UPDATE SET role = EXCLUDED.role
+	`, partnerID, userID, role)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			if pqErr.Constraint == "partner_members_partner_id_fkey" {
+				return repository.ErrNotFound
+			}
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to save partner member: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePartnerMember исключает сотрудника из организации
+func (r *PartnerRepository) DeletePartnerMember(ctx context.Context, partnerID, userID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM partner_members WHERE partner_id = $1 AND user_id = $2
+	`, partnerID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete partner member: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -25,11 +25,18 @@ type RequestRepository interface {
 	// но для решения проблемы с циклическими зависимостями достаточно определить тип
 }
 
+// PartnerRepository интерфейс для работы с партнерскими организациями
+type PartnerRepository interface {
+	// Здесь можно определить методы интерфейса PartnerRepository,
+	// но для решения проблемы с циклическими зависимостями достаточно определить тип
+}
+
 // Repository представляет собой контейнер для всех репозиториев
 type Repository struct {
 	User    interface{} // UserRepository
 	Game    interface{} // GameRepository
 	Request interface{} // RequestRepository
+	Partner interface{} // PartnerRepository
 }
 
 // NewRepository создает новый экземпляр репозитория
@@ -0,0 +1,290 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// abuseReportColumns - колонки жалобы для RETURNING и выборок
+const abuseReportColumns = `id, reporter_id, target_type, target_id, reason, details, status, action,
+	resolution_note, resolved_by, resolved_at, created_at`
+
+// abuseTargetTables - таблицы контента, который можно скрыть по жалобам
+var abuseTargetTables = map[models.AbuseReportTarget]string{
+	models.AbuseReportTargetRequest: "help_requests",
+	models.AbuseReportTargetComment: "request_comments",
+}
+
+// GetAbuseReportTargetOwner возвращает автора заявки или комментария либо самого пользователя,
+// на которого жалуются. Для удаленного или несуществующего объекта возвращается repository.ErrNotFound.
+func (r *RequestRepository) GetAbuseReportTargetOwner(ctx context.Context, target models.AbuseReportTarget, targetID int) (int, error) {
+	var query string
+	switch target {
+	case models.AbuseReportTargetRequest:
+		query = `SELECT requester_id FROM help_requests WHERE id = $1 AND is_deleted = false`
+	case models.AbuseReportTargetComment:
+		query = `SELECT user_id FROM request_comments WHERE id = $1 AND deleted_at IS NULL`
+	case models.AbuseReportTargetUser:
+		query = `SELECT id FROM users WHERE id = $1 AND is_deleted = false`
+	default:
+		return 0, fmt.Errorf("unknown report target %q", target)
+	}
+
+	var ownerID int
+	if err := r.db.GetContext(ctx, &ownerID, query, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to get report target: %w", err)
+	}
+
+	return ownerID, nil
+}
+
+// CreateAbuseReport сохраняет жалобу. Если у заявки или комментария набралось hideThreshold
+// открытых жалоб, они скрываются до решения администратора; hidden сообщает, что объект скрыт сейчас.
+// hideThreshold = 0 отключает автоматическое скрытие. Повторная открытая жалоба
+// того же пользователя на тот же объект возвращает repository.ErrConflict,
+// несуществующий объект - repository.ErrNotFound.
+func (r *RequestRepository) CreateAbuseReport(ctx context.Context, report *models.AbuseReport, hideThreshold int) (created *models.AbuseReport, hidden bool, err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Объект блокируется до вставки: одновременные жалобы на него выполняются по очереди,
+	// и каждая следующая видит в подсчете открытых жалоб все предыдущие
+	if _, err := lockAbuseReportTarget(ctx, tx, report.TargetType, report.TargetID); err != nil {
+		return nil, false, err
+	}
+
+	created = &models.AbuseReport{}
+	err = tx.GetContext(ctx, created, `
+		INSERT INTO abuse_reports (reporter_id, target_type, target_id, reason, details)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (target_type, target_id, reporter_id) WHERE status = 'open' DO NOTHING
+		RETURNING `+abuseReportColumns,
+		report.ReporterID, report.TargetType, report.TargetID, report.Reason, report.Details)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, repository.ErrConflict
+		}
+		return nil, false, fmt.Errorf("failed to create abuse report: %w", err)
+	}
+
+	table, isContent := abuseTargetTables[report.TargetType]
+	if isContent && hideThreshold > 0 {
+		var openReports int
+		err = tx.GetContext(ctx, &openReports, `
+			SELECT COUNT(*) FROM abuse_reports
+			WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+		`, report.TargetType, report.TargetID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to count open reports: %w", err)
+		}
+
+		if openReports >= hideThreshold {
+			// hidden_by остается пустым: контент скрыт автоматически
+			_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+				UPDATE %s SET hidden_at = COALESCE(hidden_at, NOW())
+				WHERE id = $1
+			`, table), report.TargetID)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to hide reported content: %w", err)
+			}
+			hidden = true
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit abuse report: %w", err)
+	}
+
+	return created, hidden, nil
+}
+
+// GetAbuseReports получает страницу жалоб в статусе status, самые давние первыми,
+// и общее количество таких жалоб
+func (r *RequestRepository) GetAbuseReports(ctx context.Context, status models.AbuseReportStatus, limit, offset int) ([]models.AbuseReport, int, error) {
+	var total int
+	if err := r.db.GetContext(ctx, &total, `SELECT COUNT(*) FROM abuse_reports WHERE status = $1`, status); err != nil {
+		return nil, 0, fmt.Errorf("failed to count abuse reports: %w", err)
+	}
+
+	query := `
+		SELECT ar.id, ar.reporter_id, ar.target_type, ar.target_id, ar.reason, ar.details, ar.status,
+			ar.action, ar.resolution_note, ar.resolved_by, ar.resolved_at, ar.created_at,
+			(SELECT COUNT(*) FROM abuse_reports o
+				WHERE o.target_type = ar.target_type AND o.target_id = ar.target_id AND o.status = 'open'
+			) as target_reports,
+			CASE ar.target_type
+				WHEN 'request' THEN EXISTS (SELECT 1 FROM help_requests h WHERE h.id = ar.target_id AND h.hidden_at IS NOT NULL)
+				WHEN 'comment' THEN EXISTS (SELECT 1 FROM request_comments c WHERE c.id = ar.target_id AND c.hidden_at IS NOT NULL)
+				ELSE EXISTS (SELECT 1 FROM users u WHERE u.id = ar.target_id AND u.suspended_at IS NOT NULL)
+			END as target_hidden
+		FROM abuse_reports ar
+		WHERE ar.status = $1
+		ORDER BY ar.created_at, ar.id
+		LIMIT $2 OFFSET $3
+	`
+
+	reports := []models.AbuseReport{}
+	if err := r.db.SelectContext(ctx, &reports, query, status, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get abuse reports: %w", err)
+	}
+
+	return reports, total, nil
+}
+
+// ResolveAbuseReport применяет решение администратора ко всем открытым жалобам на объект жалобы reportID.
+// Объект блокируется на время транзакции, поэтому одновременные решения по жалобам на него
+// выполняются по очереди. Если жалоба уже рассмотрена, возвращается repository.ErrConflict,
+// если скрыть нужно пользователя, а не контент - ErrNotHideable.
+func (r *RequestRepository) ResolveAbuseReport(ctx context.Context, reportID, adminID int, input models.AbuseReportResolveInput) (*models.AbuseReport, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var report models.AbuseReport
+	err = tx.GetContext(ctx, &report, `SELECT `+abuseReportColumns+` FROM abuse_reports WHERE id = $1`, reportID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get abuse report: %w", err)
+	}
+
+	ownerID, err := lockAbuseReportTarget(ctx, tx, report.TargetType, report.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Статус перечитывается после блокировки объекта: жалобу могли рассмотреть вместе с другой
+	if err := tx.GetContext(ctx, &report.Status, `SELECT status FROM abuse_reports WHERE id = $1`, reportID); err != nil {
+		return nil, fmt.Errorf("failed to get abuse report status: %w", err)
+	}
+	if report.Status != models.AbuseReportOpen {
+		return nil, repository.ErrConflict
+	}
+
+	table, isContent := abuseTargetTables[report.TargetType]
+	status := models.AbuseReportActioned
+
+	switch input.Action {
+	case models.AbuseReportActionDismiss:
+		status = models.AbuseReportDismissed
+		// Скрытый автоматически контент снова показывается; скрытый администратором остается скрытым
+		if isContent {
+			_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+				UPDATE %s SET hidden_at = NULL WHERE id = $1 AND hidden_by IS NULL
+			`, table), report.TargetID)
+		}
+	case models.AbuseReportActionHide, models.AbuseReportActionSuspend:
+		if input.Action == models.AbuseReportActionHide && !isContent {
+			return nil, ErrNotHideable
+		}
+		if isContent {
+			_, err = tx.ExecContext(ctx, fmt.Sprintf(`
+				UPDATE %s SET hidden_at = COALESCE(hidden_at, NOW()), hidden_by = $2 WHERE id = $1
+			`, table), report.TargetID, adminID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hide reported content: %w", err)
+			}
+		}
+		if input.Action == models.AbuseReportActionSuspend {
+			_, err = tx.ExecContext(ctx, `
+				UPDATE users SET suspended_at = COALESCE(suspended_at, NOW()), suspended_by = $2, updated_at = NOW()
+				WHERE id = $1
+			`, ownerID, adminID)
+		}
+	default:
+		return nil, fmt.Errorf("unknown report action %q", input.Action)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply report decision: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE abuse_reports
+		SET status = $3, action = $4, resolution_note = $5, resolved_by = $6, resolved_at = NOW()
+		WHERE target_type = $1 AND target_id = $2 AND status = 'open'
+	`, report.TargetType, report.TargetID, status, input.Action, input.Note, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve abuse reports: %w", err)
+	}
+
+	var resolved models.AbuseReport
+	if err := tx.GetContext(ctx, &resolved, `SELECT `+abuseReportColumns+` FROM abuse_reports WHERE id = $1`, reportID); err != nil {
+		return nil, fmt.Errorf("failed to get resolved abuse report: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit report decision: %w", err)
+	}
+
+	return &resolved, nil
+}
+
+// lockAbuseReportTarget блокирует объект жалобы в транзакции tx и возвращает его автора
+// или самого пользователя. Удаленный объект тоже блокируется: его автора можно заблокировать.
+func lockAbuseReportTarget(ctx context.Context, tx *sqlx.Tx, target models.AbuseReportTarget, targetID int) (int, error) {
+	var query string
+	switch target {
+	case models.AbuseReportTargetRequest:
+		query = `SELECT requester_id FROM help_requests WHERE id = $1 FOR UPDATE`
+	case models.AbuseReportTargetComment:
+		query = `SELECT user_id FROM request_comments WHERE id = $1 FOR UPDATE`
+	default:
+		query = `SELECT id FROM users WHERE id = $1 FOR UPDATE`
+	}
+
+	var ownerID int
+	if err := tx.GetContext(ctx, &ownerID, query, targetID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, repository.ErrNotFound
+		}
+		return 0, fmt.Errorf("failed to lock report target: %w", err)
+	}
+
+	return ownerID, nil
+}
+
+// IsUserSuspended сообщает, заблокирован ли пользователь администратором
+func (r *RequestRepository) IsUserSuspended(ctx context.Context, userID int) (bool, error) {
+	var suspended bool
+	err := r.db.GetContext(ctx, &suspended, `SELECT suspended_at IS NOT NULL FROM users WHERE id = $1`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, repository.ErrNotFound
+		}
+		return false, fmt.Errorf("failed to get user suspension: %w", err)
+	}
+
+	return suspended, nil
+}
+
+// UnsuspendUser снимает блокировку пользователя
+func (r *RequestRepository) UnsuspendUser(ctx context.Context, userID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET suspended_at = NULL, suspended_by = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to unsuspend user: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// requestReturningColumns - поля заявки, возвращаемые после ее изменения
+const requestReturningColumns = `id, title, description, status, category_id, priority, location,
+	requester_id, assigned_to, volunteer_slots, series_id, scheduled_for, due_at, is_deleted, created_at, updated_at, completed_at,
+	hidden_at IS NOT NULL as hidden`
+
+// requestVolunteer - волонтер, закрепленный за заявкой
+type requestVolunteer struct {
+	RequestID int `db:"request_id"`
+	models.UserShort
+}
+
+// ClaimRequest закрепляет за волонтером одно из свободных мест заявки.
+// Строка заявки блокируется на время транзакции, поэтому одновременные попытки
+// выполняются по очереди и не могут занять больше мест, чем есть у заявки.
+// Когда заняты все места, заявка переходит в статус "in_progress".
+// Если заявка не в статусе "new", скрыта по жалобам, мест нет или волонтер уже закреплен,
+// возвращается repository.ErrConflict.
+func (r *RequestRepository) ClaimRequest(ctx context.Context, id, volunteerID int) (*models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	request, err := lockRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.RequestStatusNew || request.Hidden {
+		return nil, repository.ErrConflict
+	}
+
+	claimed, err := claimSlot(ctx, tx, request, volunteerID, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request claim: %w", err)
+	}
+
+	return claimed, nil
+}
+
+// claimSlot закрепляет волонтера за свободным местом заявки, заблокированной в транзакции tx.
+// Переход заявки в работу записывается в историю от имени actorID.
+func claimSlot(ctx context.Context, tx *sqlx.Tx, request *models.HelpRequest, volunteerID, actorID int) (*models.HelpRequest, error) {
+	id := request.ID
+
+	taken, err := countAssignments(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if taken >= request.VolunteerSlots {
+		return nil, repository.ErrConflict
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO request_assignments (request_id, volunteer_id)
+		VALUES ($1, $2)
+		ON CONFLICT (request_id, volunteer_id) DO NOTHING
+	`, id, volunteerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign volunteer: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, repository.ErrConflict
+	}
+
+	status := models.RequestStatusNew
+	if taken+1 >= request.VolunteerSlots {
+		status = models.RequestStatusInProgress
+	}
+
+	var claimed models.HelpRequest
+	err = tx.GetContext(ctx, &claimed, `
+		UPDATE help_requests
+		SET status = $2,
+			assigned_to = COALESCE(assigned_to, $3),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+requestReturningColumns, id, status, volunteerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim request: %w", err)
+	}
+
+	if status == models.RequestStatusInProgress {
+		err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+			RequestID:  id,
+			From:       models.RequestStatusNew,
+			To:         models.RequestStatusInProgress,
+			AssignedTo: claimed.AssignedTo,
+			ActorID:    &actorID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &claimed, nil
+}
+
+// releaseSlot освобождает место волонтера в заявке внутри транзакции tx.
+// Ожидающая ответа передача заявки от этого волонтера отменяется.
+func releaseSlot(ctx context.Context, tx *sqlx.Tx, id, volunteerID int, reason string) (*models.HelpRequest, error) {
+	request, err := lockRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.RequestStatusNew && request.Status != models.RequestStatusInProgress {
+		return nil, repository.ErrConflict
+	}
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM request_assignments WHERE request_id = $1 AND volunteer_id = $2`, id, volunteerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release volunteer slot: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrNotAssigned
+	}
+
+	// Передавать больше нечего: ожидающая передача от этого волонтера отменяется
+	if err := cancelPendingHandover(ctx, tx, id, volunteerID); err != nil {
+		return nil, err
+	}
+
+	// Первым волонтером заявки становится следующий по времени закрепления
+	var released models.HelpRequest
+	err = tx.GetContext(ctx, &released, `
+		UPDATE help_requests
+		SET status = $2,
+			assigned_to = (
+				SELECT volunteer_id FROM request_assignments
+				WHERE request_id = $1
+				ORDER BY created_at, id
+				LIMIT 1
+			),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+requestReturningColumns, id, models.RequestStatusNew)
+	if err != nil {
+		return nil, fmt.Errorf("failed to release volunteer slot: %w", err)
+	}
+
+	if request.Status == models.RequestStatusInProgress {
+		err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+			RequestID:  id,
+			From:       models.RequestStatusInProgress,
+			To:         models.RequestStatusNew,
+			AssignedTo: released.AssignedTo,
+			ActorID:    &volunteerID,
+			Reason:     reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &released, nil
+}
+
+// GetRequestVolunteers получает волонтеров, закрепленных за заявкой, в порядке закрепления
+func (r *RequestRepository) GetRequestVolunteers(ctx context.Context, requestID int) ([]models.UserShort, error) {
+	volunteers, err := r.getVolunteersByRequest(ctx, []int{requestID})
+	if err != nil {
+		return nil, err
+	}
+
+	return volunteers[requestID], nil
+}
+
+// getVolunteersByRequest получает волонтеров сразу для нескольких заявок
+func (r *RequestRepository) getVolunteersByRequest(ctx context.Context, requestIDs []int) (map[int][]models.UserShort, error) {
+	query := `
+		SELECT
+			a.request_id, u.id, u.username, COALESCE(u.first_name, '') as first_name,
+			COALESCE(u.last_name, '') as last_name, COALESCE(u.photo_url, '') as photo_url
+		FROM request_assignments a
+		INNER JOIN users u ON a.volunteer_id = u.id
+		WHERE a.request_id = ANY($1)
+		ORDER BY a.created_at, a.id
+	`
+
+	var rows []requestVolunteer
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(requestIDs)); err != nil {
+		return nil, fmt.Errorf("failed to get request volunteers: %w", err)
+	}
+
+	volunteers := make(map[int][]models.UserShort, len(requestIDs))
+	for _, row := range rows {
+		volunteers[row.RequestID] = append(volunteers[row.RequestID], row.UserShort)
+	}
+
+	return volunteers, nil
+}
+
+// attachVolunteers заполняет список волонтеров у заявок страницы
+func (r *RequestRepository) attachVolunteers(ctx context.Context, requests []models.RequestFullInfo) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(requests))
+	for i := range requests {
+		ids[i] = requests[i].ID
+	}
+
+	volunteers, err := r.getVolunteersByRequest(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range requests {
+		requests[i].Volunteers = volunteers[requests[i].ID]
+	}
+
+	return nil
+}
+
+// lockRequest получает заявку и блокирует ее строку до конца транзакции
+func lockRequest(ctx context.Context, tx *sqlx.Tx, id int) (*models.HelpRequest, error) {
+	var request models.HelpRequest
+	err := tx.GetContext(ctx, &request, `
+		SELECT `+requestReturningColumns+`
+		FROM help_requests
+		WHERE id = $1 AND is_deleted = false
+		FOR UPDATE
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock request: %w", err)
+	}
+
+	return &request, nil
+}
+
+// countAssignments возвращает количество занятых мест заявки
+func countAssignments(ctx context.Context, tx *sqlx.Tx, requestID int) (int, error) {
+	var count int
+	err := tx.GetContext(ctx, &count, `SELECT COUNT(*) FROM request_assignments WHERE request_id = $1`, requestID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count request assignments: %w", err)
+	}
+
+	return count, nil
+}
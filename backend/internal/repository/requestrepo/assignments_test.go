@@ -0,0 +1,120 @@
+package requestrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// openTestDB подключается к тестовой базе PostgreSQL с примененными миграциями.
+// Без TEST_DATABASE_URL тест пропускается.
+func openTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+// createTestUser создает пользователя с ролью role и удаляет его после теста
+func createTestUser(t *testing.T, db *sqlx.DB, role models.UserRole) int {
+	t.Helper()
+
+	var id int
+	err := db.Get(&id, `INSERT INTO users (first_name, role) VALUES ($1, $2) RETURNING id`,
+		fmt.Sprintf("test-%s", role), role)
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	t.Cleanup(func() { db.Exec(`DELETE FROM users WHERE id = $1`, id) })
+
+	return id
+}
+
+func TestClaimRequestConcurrent(t *testing.T) {
+	db := openTestDB(t)
+	repo := NewRequestRepository(db, logrus.New())
+	ctx := context.Background()
+
+	requesterID := createTestUser(t, db, models.UserRoleUser)
+
+	const volunteers = 20
+	volunteerIDs := make([]int, volunteers)
+	for i := range volunteerIDs {
+		volunteerIDs[i] = createTestUser(t, db, models.UserRoleVolunteer)
+	}
+
+	var requestID int
+	err := db.Get(&requestID, `
+		INSERT INTO help_requests (title, requester_id, volunteer_slots)
+		VALUES ('Одна свободная позиция', $1, 1)
+		RETURNING id
+	`, requesterID)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	// Заявка удаляется раньше пользователей: очистка выполняется в обратном порядке
+	t.Cleanup(func() { db.Exec(`DELETE FROM help_requests WHERE id = $1`, requestID) })
+
+	start := make(chan struct{})
+	errs := make([]error, volunteers)
+	var wg sync.WaitGroup
+	for i, volunteerID := range volunteerIDs {
+		wg.Add(1)
+		go func(i, volunteerID int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = repo.ClaimRequest(ctx, requestID, volunteerID)
+		}(i, volunteerID)
+	}
+	close(start)
+	wg.Wait()
+
+	claimed := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			claimed++
+		case errors.Is(err, repository.ErrConflict):
+		default:
+			t.Errorf("volunteer %d: unexpected error: %v", volunteerIDs[i], err)
+		}
+	}
+	if claimed != 1 {
+		t.Fatalf("claimed by %d volunteers, want exactly 1", claimed)
+	}
+
+	var assignments int
+	if err := db.Get(&assignments, `SELECT COUNT(*) FROM request_assignments WHERE request_id = $1`, requestID); err != nil {
+		t.Fatalf("failed to count assignments: %v", err)
+	}
+	if assignments != 1 {
+		t.Errorf("request has %d assignments, want 1", assignments)
+	}
+
+	var status models.RequestStatus
+	if err := db.Get(&status, `SELECT status FROM help_requests WHERE id = $1`, requestID); err != nil {
+		t.Fatalf("failed to get request status: %v", err)
+	}
+	if status != models.RequestStatusInProgress {
+		t.Errorf("request status = %q, want %q", status, models.RequestStatusInProgress)
+	}
+}
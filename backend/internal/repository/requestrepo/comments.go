@@ -0,0 +1,192 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+// commentSelect выбирает комментарий вместе с автором
+const commentSelect = `
+	SELECT
+		c.id, c.request_id, c.user_id, c.parent_id, c.text, c.created_at, c.updated_at,
+		c.edited_at, c.deleted_at,
+		(c.deleted_by IS NOT NULL AND c.deleted_by <> c.user_id) as deleted_by_moderator,
+		c.hidden_at IS NOT NULL as hidden,
+		COALESCE(u.username, '') as author_username,
+		COALESCE(u.first_name, '') as author_first_name,
+		COALESCE(u.last_name, '') as author_last_name,
+		COALESCE(u.photo_url, '') as author_photo_url
+	FROM request_comments c
+	LEFT JOIN users u ON c.user_id = u.id`
+
+// commentVisibleSQL - условие видимости комментария в ветке. Комментарий, удаленный автором,
+// скрывается, если у него не осталось ответов; удаленный администратором остается отметкой.
+const commentVisibleSQL = `(c.deleted_at IS NULL OR c.deleted_by IS DISTINCT FROM c.user_id OR EXISTS (
+		SELECT 1 FROM request_comments rc WHERE rc.parent_id = c.id AND rc.deleted_at IS NULL
+	))`
+
+// commentRow - строка комментария с автором
+type commentRow struct {
+	models.RequestComment
+	AuthorUsername  string `db:"author_username"`
+	AuthorFirstName string `db:"author_first_name"`
+	AuthorLastName  string `db:"author_last_name"`
+	AuthorPhotoURL  string `db:"author_photo_url"`
+}
+
+// comment возвращает комментарий. У удаленного комментария скрываются текст и автор,
+// у скрытого по жалобам - текст.
+func (row commentRow) comment() models.RequestComment {
+	comment := row.RequestComment
+	if comment.DeletedAt != nil {
+		comment.Text = ""
+		return comment
+	}
+	if comment.Hidden {
+		comment.Text = ""
+	}
+	comment.User = &models.UserShort{
+		ID:        comment.UserID,
+		Username:  row.AuthorUsername,
+		FirstName: row.AuthorFirstName,
+		LastName:  row.AuthorLastName,
+		PhotoURL:  row.AuthorPhotoURL,
+	}
+	return comment
+}
+
+// GetRequestComments возвращает страницу веток комментариев заявки, от старых к новым.
+// Пагинация идет по комментариям верхнего уровня; к каждому добавляются все его ответы.
+// Удаленные комментарии возвращаются отметками без текста (см. commentVisibleSQL).
+func (r *RequestRepository) GetRequestComments(ctx context.Context, requestID, limit, offset int) ([]models.RequestComment, int, error) {
+	rootsQuery := commentSelect + `
+		WHERE c.request_id = $1 AND c.parent_id IS NULL
+			AND ` + commentVisibleSQL + `
+		ORDER BY c.created_at, c.id
+		LIMIT $2 OFFSET $3
+	`
+
+	var roots []commentRow
+	if err := r.db.SelectContext(ctx, &roots, rootsQuery, requestID, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	var total int
+	countQuery := `
+		SELECT COUNT(*) FROM request_comments c
+		WHERE c.request_id = $1 AND c.parent_id IS NULL
+			AND ` + commentVisibleSQL + `
+	`
+	if err := r.db.GetContext(ctx, &total, countQuery, requestID); err != nil {
+		return nil, 0, fmt.Errorf("failed to count comments: %w", err)
+	}
+
+	comments := make([]models.RequestComment, 0, len(roots))
+	if len(roots) == 0 {
+		return comments, total, nil
+	}
+
+	rootIDs := make([]int64, len(roots))
+	index := make(map[int]int, len(roots))
+	for i, row := range roots {
+		rootIDs[i] = int64(row.ID)
+		index[row.ID] = i
+		comments = append(comments, row.comment())
+	}
+
+	repliesQuery := commentSelect + `
+		WHERE c.parent_id = ANY($1) AND ` + commentVisibleSQL + `
+		ORDER BY c.created_at, c.id
+	`
+
+	var replies []commentRow
+	if err := r.db.SelectContext(ctx, &replies, repliesQuery, pq.Array(rootIDs)); err != nil {
+		return nil, 0, fmt.Errorf("failed to get comment replies: %w", err)
+	}
+
+	for _, row := range replies {
+		i := index[*row.ParentID]
+		comments[i].Replies = append(comments[i].Replies, row.comment())
+	}
+
+	return comments, total, nil
+}
+
+// GetCommentByID возвращает комментарий заявки, включая удаленный
+func (r *RequestRepository) GetCommentByID(ctx context.Context, requestID, commentID int) (*models.RequestComment, error) {
+	var row commentRow
+	err := r.db.GetContext(ctx, &row, commentSelect+` WHERE c.id = $1 AND c.request_id = $2`, commentID, requestID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	comment := row.comment()
+	return &comment, nil
+}
+
+// AddComment добавляет комментарий к запросу
+func (r *RequestRepository) AddComment(ctx context.Context, comment *models.RequestComment) (*models.RequestComment, error) {
+	query := `
+		INSERT INTO request_comments (request_id, user_id, parent_id, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+
+	var id int
+	err := r.db.GetContext(ctx, &id, query, comment.RequestID, comment.UserID, comment.ParentID, comment.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return r.GetCommentByID(ctx, comment.RequestID, id)
+}
+
+// UpdateComment заменяет текст комментария и отмечает его измененным.
+// Удаленный комментарий не изменяется: возвращается repository.ErrNotFound.
+func (r *RequestRepository) UpdateComment(ctx context.Context, requestID, commentID int, text string) (*models.RequestComment, error) {
+	query := `
+		UPDATE request_comments
+		SET text = $3, edited_at = NOW()
+		WHERE id = $1 AND request_id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, commentID, requestID, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, repository.ErrNotFound
+	}
+
+	return r.GetCommentByID(ctx, requestID, commentID)
+}
+
+// DeleteComment удаляет комментарий, оставляя на его месте отметку об удалении.
+// Повторное удаление возвращает repository.ErrNotFound.
+func (r *RequestRepository) DeleteComment(ctx context.Context, requestID, commentID, actorID int) error {
+	query := `
+		UPDATE request_comments
+		SET deleted_at = NOW(), deleted_by = $3
+		WHERE id = $1 AND request_id = $2 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, commentID, requestID, actorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package requestrepo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// ErrInvalidCursor возвращается, если курсор страницы не удалось разобрать
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// requestCursor - позиция последней заявки страницы в порядке ленты (см. requestOrder).
+// Клиенту передается в виде непрозрачной base64-строки.
+type requestCursor struct {
+	Sort      models.RequestSort     `json:"o,omitempty"`
+	Status    models.RequestStatus   `json:"s"`
+	Priority  models.RequestPriority `json:"p"`
+	CreatedAt time.Time              `json:"c"`
+	DueAt     *time.Time             `json:"d,omitempty"`
+	ID        int                    `json:"i"`
+}
+
+// encodeRequestCursor формирует курсор, указывающий на позицию после заявки
+func encodeRequestCursor(request models.RequestFullInfo, sort models.RequestSort) string {
+	data, _ := json.Marshal(requestCursor{
+		Sort:      sort,
+		Status:    request.Status,
+		Priority:  request.Priority,
+		CreatedAt: request.CreatedAt,
+		DueAt:     request.DueAt,
+		ID:        request.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeRequestCursor разбирает курсор, полученный от клиента.
+// Курсор, выданный для другой сортировки, считается недействительным.
+func decodeRequestCursor(cursor string, sort models.RequestSort) (*requestCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c requestCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 || c.CreatedAt.IsZero() || c.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// buildRequestCursorCondition формирует условие "после курсора" для порядка ленты
+func buildRequestCursorCondition(c *requestCursor, params []interface{}) (string, []interface{}) {
+	if c.Sort == models.RequestSortDueSoon {
+		return buildDueSoonCursorCondition(c, params)
+	}
+	return buildDefaultCursorCondition(c, params)
+}
+
+// buildDefaultCursorCondition формирует условие "после курсора" для порядка requestDefaultOrder:
+// ранг статуса и приоритета по возрастанию, затем created_at и id по убыванию
+func buildDefaultCursorCondition(c *requestCursor, params []interface{}) (string, []interface{}) {
+	params = append(params, string(c.Status), string(c.Priority), c.CreatedAt, c.ID)
+	status, priority, createdAt, id := len(params)-3, len(params)-2, len(params)-1, len(params)
+
+	statusRank := statusRankSQL("r.status")
+	cursorStatusRank := statusRankSQL(fmt.Sprintf("$%d::request_status", status))
+	priorityRank := priorityRankSQL("r.priority")
+	cursorPriorityRank := priorityRankSQL(fmt.Sprintf("$%d::request_priority", priority))
+
+	condition := fmt.Sprintf(` AND (
+		%[1]s > %[2]s OR (%[1]s = %[2]s AND (
+			%[3]s > %[4]s OR (%[3]s = %[4]s AND (
+				r.created_at < $%[5]d OR (r.created_at = $%[5]d AND r.id < $%[6]d)
+			))
+		))
+	)`, statusRank, cursorStatusRank, priorityRank, cursorPriorityRank, createdAt, id)
+
+	return condition, params
+}
+
+// buildDueSoonCursorCondition формирует условие "после курсора" для порядка requestDueSoonOrder:
+// due_at и id по возрастанию, заявки без срока идут после всех заявок со сроком
+func buildDueSoonCursorCondition(c *requestCursor, params []interface{}) (string, []interface{}) {
+	if c.DueAt == nil {
+		params = append(params, c.ID)
+		return fmt.Sprintf(` AND r.due_at IS NULL AND r.id > $%d`, len(params)), params
+	}
+
+	params = append(params, *c.DueAt, c.ID)
+	dueAt, id := len(params)-1, len(params)
+
+	condition := fmt.Sprintf(` AND (
+		r.due_at IS NULL OR r.due_at > $%[1]d OR (r.due_at = $%[1]d AND r.id > $%[2]d)
+	)`, dueAt, id)
+
+	return condition, params
+}
@@ -0,0 +1,143 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AssignRequest закрепляет волонтера за свободным местом заявки по решению координатора adminID.
+// Если у волонтера уже maxActive незавершенных заявок, возвращается ErrVolunteerBusy.
+// Если заявка не в статусе "new", скрыта по жалобам, мест нет или волонтер уже закреплен,
+// возвращается repository.ErrConflict.
+func (r *RequestRepository) AssignRequest(ctx context.Context, id, volunteerID, adminID, maxActive int) (*models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	request, err := lockRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.RequestStatusNew || request.Hidden {
+		return nil, repository.ErrConflict
+	}
+
+	if err := checkVolunteerLoad(ctx, tx, volunteerID, maxActive); err != nil {
+		return nil, err
+	}
+
+	assigned, err := claimSlot(ctx, tx, request, volunteerID, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request assignment: %w", err)
+	}
+
+	return assigned, nil
+}
+
+// ReassignRequest передает место волонтера fromID в заявке волонтеру toID по решению координатора.
+// Ожидающая передача заявки от fromID отменяется. Если fromID не закреплен за заявкой,
+// возвращается ErrNotAssigned, если у toID уже maxActive незавершенных заявок - ErrVolunteerBusy.
+// Скрытую по жалобам заявку передать нельзя, возвращается repository.ErrConflict.
+func (r *RequestRepository) ReassignRequest(ctx context.Context, id, fromID, toID, maxActive int) (*models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	request, err := lockRequest(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if (request.Status != models.RequestStatusNew && request.Status != models.RequestStatusInProgress) || request.Hidden {
+		return nil, repository.ErrConflict
+	}
+
+	if err := checkVolunteerLoad(ctx, tx, toID, maxActive); err != nil {
+		return nil, err
+	}
+
+	reassigned, err := transferSlot(ctx, tx, id, fromID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cancelPendingHandover(ctx, tx, id, fromID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request reassignment: %w", err)
+	}
+
+	return reassigned, nil
+}
+
+// GetVolunteerLoads возвращает волонтеров с числом их незавершенных заявок, начиная с наименее загруженных
+func (r *RequestRepository) GetVolunteerLoads(ctx context.Context) ([]models.VolunteerLoad, error) {
+	query := `
+		SELECT
+			u.id, u.username, COALESCE(u.first_name, '') as first_name,
+			COALESCE(u.last_name, '') as last_name, COALESCE(u.photo_url, '') as photo_url,
+			COUNT(h.id) as active_requests
+		FROM users u
+		LEFT JOIN request_assignments a ON a.volunteer_id = u.id
+		LEFT JOIN help_requests h ON a.request_id = h.id
+			AND h.status IN ('new', 'in_progress') AND h.is_deleted = false
+		WHERE u.role = 'volunteer'
+		GROUP BY u.id
+		ORDER BY active_requests, u.id
+	`
+
+	var loads []models.VolunteerLoad
+	if err := r.db.SelectContext(ctx, &loads, query); err != nil {
+		return nil, fmt.Errorf("failed to get volunteer loads: %w", err)
+	}
+
+	return loads, nil
+}
+
+// checkVolunteerLoad проверяет, что у волонтера меньше maxActive незавершенных заявок.
+// Строка волонтера блокируется до конца транзакции, поэтому одновременные назначения
+// одного волонтера на разные заявки не превысят ограничение.
+func checkVolunteerLoad(ctx context.Context, tx *sqlx.Tx, volunteerID, maxActive int) error {
+	var locked int
+	err := tx.GetContext(ctx, &locked, `SELECT id FROM users WHERE id = $1 FOR NO KEY UPDATE`, volunteerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to lock volunteer: %w", err)
+	}
+
+	var active int
+	err = tx.GetContext(ctx, &active, `
+		SELECT COUNT(*)
+		FROM request_assignments a
+		INNER JOIN help_requests h ON a.request_id = h.id
+		WHERE a.volunteer_id = $1 AND h.status IN ('new', 'in_progress') AND h.is_deleted = false
+	`, volunteerID)
+	if err != nil {
+		return fmt.Errorf("failed to count volunteer requests: %w", err)
+	}
+	if active >= maxActive {
+		return ErrVolunteerBusy
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+// escalationRuleColumns - поля правила эскалации
+const escalationRuleColumns = `id, category_id, priority, threshold_minutes, raise_priority, created_at, updated_at`
+
+// GetEscalationRules получает все правила эскалации: сначала общие, затем по категориям
+func (r *RequestRepository) GetEscalationRules(ctx context.Context) ([]models.EscalationRule, error) {
+	query := `
+		SELECT ` + escalationRuleColumns + `
+		FROM request_escalation_rules
+		ORDER BY category_id NULLS FIRST, ` + priorityRankSQL("priority")
+
+	var rules []models.EscalationRule
+	if err := r.db.SelectContext(ctx, &rules, query); err != nil {
+		return nil, fmt.Errorf("failed to get escalation rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// SaveEscalationRule создает правило эскалации или заменяет правило
+// с той же категорией и приоритетом
+func (r *RequestRepository) SaveEscalationRule(ctx context.Context, rule *models.EscalationRule) (*models.EscalationRule, error) {
+	query := `
+		INSERT INTO request_escalation_rules (category_id, priority, threshold_minutes, raise_priority)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT ((COALESCE(category_id, 0)), priority) DO UPDATE
+		SET threshold_minutes = EXCLUDED.threshold_minutes,
+			raise_priority = EXCLUDED.raise_priority
+		RETURNING ` + escalationRuleColumns
+
+	var saved models.EscalationRule
+	err := r.db.GetContext(ctx, &saved, query, rule.CategoryID, rule.Priority, rule.ThresholdMinutes, rule.RaisePriority)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return nil, ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to save escalation rule: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// DeleteEscalationRule удаляет правило эскалации
+func (r *RequestRepository) DeleteEscalationRule(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM request_escalation_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete escalation rule: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetEscalationCandidates получает не более limit заявок без волонтера, которые ждут дольше порога
+// своего правила. Ожидание отсчитывается от последней эскалации, а если ее не было - от создания заявки.
+// Заявки, достигшие maxLevel эскалаций, и заявки, скрытые по жалобам, не возвращаются.
+func (r *RequestRepository) GetEscalationCandidates(ctx context.Context, now time.Time, maxLevel, limit int) ([]models.EscalationCandidate, error) {
+	query := `
+		SELECT
+			r.id as request_id, r.title, r.requester_id, COALESCE(r.category_id, 0) as category_id, r.priority, r.created_at,
+			COALESCE(e.level, 0) as level,
+			rule.id as rule_id, rule.threshold_minutes, rule.raise_priority
+		FROM help_requests r
+		LEFT JOIN LATERAL (
+			SELECT MAX(level) as level, MAX(created_at) as last_at
+			FROM request_escalations
+			WHERE request_id = r.id
+		) e ON true
+		INNER JOIN LATERAL (
+			SELECT er.id, er.threshold_minutes, er.raise_priority
+			FROM request_escalation_rules er
+			WHERE er.priority = r.priority AND (er.category_id = r.category_id OR er.category_id IS NULL)
+			ORDER BY er.category_id NULLS LAST
+			LIMIT 1
+		) rule ON true
+		WHERE r.status = 'new' AND r.is_deleted = false AND r.assigned_to IS NULL AND r.hidden_at IS NULL
+			AND COALESCE(e.level, 0) < $1
+			AND COALESCE(e.last_at, r.created_at) + make_interval(mins => rule.threshold_minutes) <= $2
+		ORDER BY COALESCE(e.last_at, r.created_at)
+		LIMIT $3
+	`
+
+	var candidates []models.EscalationCandidate
+	if err := r.db.SelectContext(ctx, &candidates, query, maxLevel, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to get escalation candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// GetEscalationRecipients получает получателей уведомления об эскалации:
+// всех администраторов и не более volunteerLimit волонтеров, чаще других
+// выполнявших заявки этой категории. Заблокированные волонтеры и волонтеры,
+// которых автор заявки внес в черный список, не уведомляются.
+func (r *RequestRepository) GetEscalationRecipients(ctx context.Context, requesterID, categoryID, volunteerLimit int) ([]int, error) {
+	query := `
+		SELECT id FROM users WHERE role = 'admin' AND is_deleted = false
+		UNION
+		SELECT volunteer_id FROM (
+			SELECT a.volunteer_id
+			FROM request_assignments a
+			INNER JOIN help_requests h ON a.request_id = h.id
+			INNER JOIN users u ON a.volunteer_id = u.id
+			WHERE h.category_id = $1 AND h.status = 'completed'
+				AND u.role = 'volunteer' AND u.is_deleted = false AND u.suspended_at IS NULL
+				AND NOT EXISTS (
+					SELECT 1 FROM user_blocks b
+					WHERE b.blocker_id = $3 AND b.blocked_id = a.volunteer_id
+				)
+			GROUP BY a.volunteer_id
+			ORDER BY COUNT(*) DESC, MAX(h.completed_at) DESC
+			LIMIT $2
+		) v
+	`
+
+	var recipients []int
+	if err := r.db.SelectContext(ctx, &recipients, query, categoryID, volunteerLimit, requesterID); err != nil {
+		return nil, fmt.Errorf("failed to get escalation recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// EscalateRequest записывает эскалацию заявки и, если приоритет повышается, меняет его.
+// Эскалация выполняется только если заявка все еще ищет волонтера, ее приоритет
+// равен escalation.FromPriority, а эскалация этого уровня еще не записана,
+// иначе возвращается repository.ErrConflict.
+func (r *RequestRepository) EscalateRequest(ctx context.Context, escalation *models.RequestEscalation) (*models.RequestEscalation, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	request, err := lockRequest(ctx, tx, escalation.RequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.RequestStatusNew || request.AssignedTo != nil || request.Priority != escalation.FromPriority {
+		return nil, repository.ErrConflict
+	}
+
+	var created models.RequestEscalation
+	err = tx.GetContext(ctx, &created, `
+		INSERT INTO request_escalations (
+			request_id, rule_id, level, from_priority, to_priority, threshold_minutes, waited_minutes, notified_count
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (request_id, level) DO NOTHING
+		RETURNING id, request_id, rule_id, level, from_priority, to_priority, threshold_minutes,
+			waited_minutes, notified_count, created_at
+	`, escalation.RequestID, escalation.RuleID, escalation.Level, escalation.FromPriority, escalation.ToPriority,
+		escalation.ThresholdMinutes, escalation.WaitedMinutes, escalation.NotifiedCount)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// Эскалацию этого уровня уже записал другой экземпляр сервера
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to record request escalation: %w", err)
+	}
+
+	if escalation.ToPriority != escalation.FromPriority {
+		_, err = tx.ExecContext(ctx,
+			`UPDATE help_requests SET priority = $2, updated_at = NOW() WHERE id = $1`,
+			escalation.RequestID, escalation.ToPriority)
+		if err != nil {
+			return nil, fmt.Errorf("failed to raise request priority: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request escalation: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetRequestEscalations получает журнал эскалаций заявки в хронологическом порядке
+func (r *RequestRepository) GetRequestEscalations(ctx context.Context, requestID int) ([]models.RequestEscalation, error) {
+	query := `
+		SELECT id, request_id, rule_id, level, from_priority, to_priority, threshold_minutes,
+			waited_minutes, notified_count, created_at
+		FROM request_escalations
+		WHERE request_id = $1
+		ORDER BY level
+	`
+
+	var escalations []models.RequestEscalation
+	if err := r.db.SelectContext(ctx, &escalations, query, requestID); err != nil {
+		return nil, fmt.Errorf("failed to get request escalations: %w", err)
+	}
+
+	return escalations, nil
+}
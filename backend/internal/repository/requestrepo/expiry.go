@@ -0,0 +1,57 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// ExpireOverdueRequests переводит в статус "expired" не более limit заявок в статусе "new",
+// срок которых наступил до now, и записывает переходы в историю без автора.
+// Заблокированные другими транзакциями заявки пропускаются: их волонтер
+// может как раз брать заявку, и они будут проверены при следующем запуске.
+func (r *RequestRepository) ExpireOverdueRequests(ctx context.Context, now time.Time, limit int) ([]models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var expired []models.HelpRequest
+	err = tx.SelectContext(ctx, &expired, `
+		UPDATE help_requests
+		SET status = $1, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM help_requests
+			WHERE status = $2 AND is_deleted = false AND due_at IS NOT NULL AND due_at <= $3
+			ORDER BY due_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+requestReturningColumns,
+		models.RequestStatusExpired, models.RequestStatusNew, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire overdue requests: %w", err)
+	}
+
+	for _, request := range expired {
+		err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+			RequestID:  request.ID,
+			From:       models.RequestStatusNew,
+			To:         models.RequestStatusExpired,
+			AssignedTo: request.AssignedTo,
+			Reason:     "due date has passed",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request expiry: %w", err)
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,99 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// ExportRequests передает fn заявки под фильтр от старых к новым.
+// Заявки читаются из базы построчно, поэтому выгрузка не ограничена памятью.
+func (r *RequestRepository) ExportRequests(ctx context.Context, filter models.RequestFilter, fn func(*models.RequestFullInfo) error) error {
+	whereClause, params := buildRequestFilter(filter, nil)
+	query := fmt.Sprintf(`%s %s %s ORDER BY r.created_at, r.id`,
+		requestFullInfoSelect, requestFullInfoFrom, whereClause)
+
+	rows, err := r.db.QueryxContext(ctx, query, params...)
+	if err != nil {
+		return fmt.Errorf("failed to export requests: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var request models.RequestFullInfo
+		if err := rows.StructScan(&request); err != nil {
+			return fmt.Errorf("failed to scan exported request: %w", err)
+		}
+		if err := fn(&request); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// ExportVolunteerActivity передает fn активность волонтеров за период: заявки, выполненные
+// в периоде, время, засчитанное по отметкам, закрытым в периоде, и оценки, полученные в периоде.
+// Волонтеры без активности пропускаются, самые активные идут первыми.
+func (r *RequestRepository) ExportVolunteerActivity(ctx context.Context, filter models.VolunteerActivityFilter, fn func(*models.VolunteerActivity) error) error {
+	query := `
+		SELECT
+			u.id as volunteer_id, COALESCE(u.username, '') as username,
+			COALESCE(u.first_name, '') as first_name, COALESCE(u.last_name, '') as last_name,
+			COALESCE(done.completed_requests, 0) as completed_requests,
+			COALESCE(worked.minutes, 0) as minutes,
+			COALESCE(rated.ratings_count, 0) as ratings_count,
+			rated.average_rating
+		FROM users u
+		LEFT JOIN (
+			SELECT a.volunteer_id, COUNT(*) as completed_requests
+			FROM request_assignments a
+			INNER JOIN help_requests h ON a.request_id = h.id
+			WHERE h.status = 'completed' AND h.is_deleted = false
+				AND h.completed_at >= $1 AND h.completed_at < $2
+				AND ($3 = 0 OR h.partner_id = $3)
+			GROUP BY a.volunteer_id
+		) done ON done.volunteer_id = u.id
+		LEFT JOIN (
+			SELECT e.volunteer_id, SUM(e.minutes) as minutes
+			FROM request_time_entries e
+			INNER JOIN help_requests h ON e.request_id = h.id
+			WHERE e.checked_out_at >= $1 AND e.checked_out_at < $2
+				AND h.is_deleted = false
+				AND ($3 = 0 OR h.partner_id = $3)
+			GROUP BY e.volunteer_id
+		) worked ON worked.volunteer_id = u.id
+		LEFT JOIN (
+			-- Оценки, которые волонтер получил от автора заявки
+			SELECT rr.rated_id, COUNT(*) as ratings_count, ROUND(AVG(rr.rating), 2)::float8 as average_rating
+			FROM request_ratings rr
+			INNER JOIN help_requests h ON rr.request_id = h.id
+			WHERE rr.created_at >= $1 AND rr.created_at < $2
+				AND rr.rated_id <> h.requester_id
+				AND h.is_deleted = false
+				AND ($3 = 0 OR h.partner_id = $3)
+			GROUP BY rr.rated_id
+		) rated ON rated.rated_id = u.id
+		WHERE done.volunteer_id IS NOT NULL OR worked.volunteer_id IS NOT NULL OR rated.rated_id IS NOT NULL
+		ORDER BY completed_requests DESC, minutes DESC, u.id
+	`
+
+	rows, err := r.db.QueryxContext(ctx, query, filter.From, filter.To, filter.PartnerID)
+	if err != nil {
+		return fmt.Errorf("failed to export volunteer activity: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activity models.VolunteerActivity
+		if err := rows.StructScan(&activity); err != nil {
+			return fmt.Errorf("failed to scan volunteer activity: %w", err)
+		}
+		if err := fn(&activity); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
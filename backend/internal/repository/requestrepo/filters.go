@@ -0,0 +1,121 @@
+package requestrepo
+
+import (
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// requestFullInfoSelect выбирает поля models.RequestFullInfo для списков заявок.
+// Алиас таблицы заявок - r, категорий - c.
+const requestFullInfoSelect = `
+	SELECT
+		r.id, r.title, r.description, r.status,
+		COALESCE(c.name, '') as category_name, COALESCE(c.icon, '') as category_icon, COALESCE(c.color, '') as category_color,
+		r.priority, COALESCE(r.location, '') as location, r.location_lat, r.location_lon,
+		r.created_at, r.updated_at, r.completed_at,
+		r.requester_id, COALESCE(u1.username, '') as requester_username, COALESCE(u1.first_name, '') as requester_first_name,
+		COALESCE(u1.last_name, '') as requester_last_name, COALESCE(u1.photo_url, '') as requester_photo_url,
+		r.assigned_to, u2.username as volunteer_username, u2.first_name as volunteer_first_name,
+		u2.last_name as volunteer_last_name, u2.photo_url as volunteer_photo_url,
+		r.volunteer_slots, r.series_id, r.scheduled_for, r.due_at,
+		p.id as partner_id, p.name as partner_name,
+		(SELECT COUNT(*) FROM request_comments rc WHERE rc.request_id = r.id) as comments_count`
+
+// requestFullInfoFrom соединяет заявку с автором, волонтером, категорией
+// и проверенной партнерской организацией (алиас p)
+const requestFullInfoFrom = `
+	FROM help_requests r
+	LEFT JOIN users u1 ON r.requester_id = u1.id
+	LEFT JOIN users u2 ON r.assigned_to = u2.id
+	LEFT JOIN request_categories c ON r.category_id = c.id
+	LEFT JOIN partner_organizations p ON r.partner_id = p.id AND p.is_verified`
+
+// statusRankSQL возвращает SQL-выражение ранга статуса в ленте: сначала открытые заявки
+func statusRankSQL(expr string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]s = 'new' THEN 1
+		 WHEN %[1]s = 'in_progress' THEN 2
+		 WHEN %[1]s = 'completed' THEN 3
+		 ELSE 4
+	END`, expr)
+}
+
+// priorityRankSQL возвращает SQL-выражение ранга приоритета: сначала срочные заявки
+func priorityRankSQL(expr string) string {
+	return fmt.Sprintf(`CASE WHEN %[1]s = 'high' THEN 1
+		 WHEN %[1]s = 'medium' THEN 2
+		 ELSE 3
+	END`, expr)
+}
+
+// requestDefaultOrder - порядок ленты заявок: статус, приоритет, новизна.
+// id завершает порядок, чтобы позиция в ленте была однозначной для курсора.
+var requestDefaultOrder = fmt.Sprintf(`%s, %s, r.created_at DESC, r.id DESC`,
+	statusRankSQL("r.status"), priorityRankSQL("r.priority"))
+
+// requestDueSoonOrder - порядок "скоро срок": сначала ближайший срок, заявки без срока в конце
+const requestDueSoonOrder = `r.due_at ASC NULLS LAST, r.id ASC`
+
+// requestOrder возвращает порядок ленты заявок для выбранной сортировки
+func requestOrder(sort models.RequestSort) string {
+	if sort == models.RequestSortDueSoon {
+		return requestDueSoonOrder
+	}
+	return requestDefaultOrder
+}
+
+// buildRequestFilter формирует условие WHERE для фильтров заявки.
+// Параметры добавляются к params, нумерация плейсхолдеров продолжается с len(params)+1.
+func buildRequestFilter(filter models.RequestFilter, params []interface{}) (string, []interface{}) {
+	whereClause := "WHERE r.is_deleted = false"
+
+	// Заявки на проверке, отклоненные и скрытые по жалобам видны в ленте только их автору
+	if filter.RequesterID == 0 {
+		whereClause += " AND r.status NOT IN ('pending_review', 'changes_requested', 'rejected') AND r.hidden_at IS NULL"
+	}
+
+	if filter.Status != "" {
+		params = append(params, filter.Status)
+		whereClause += fmt.Sprintf(" AND r.status = $%d", len(params))
+	}
+
+	if filter.Category != "" {
+		params = append(params, filter.Category)
+		whereClause += fmt.Sprintf(" AND c.name = $%d", len(params))
+	}
+
+	if filter.Priority != "" {
+		params = append(params, filter.Priority)
+		whereClause += fmt.Sprintf(" AND r.priority = $%d", len(params))
+	}
+
+	if filter.RequesterID != 0 {
+		params = append(params, filter.RequesterID)
+		whereClause += fmt.Sprintf(" AND r.requester_id = $%d", len(params))
+	}
+
+	// Волонтер может занимать любое из мест заявки, а не только первое
+	if filter.VolunteerID != 0 {
+		params = append(params, filter.VolunteerID)
+		whereClause += fmt.Sprintf(
+			" AND EXISTS (SELECT 1 FROM request_assignments a WHERE a.request_id = r.id AND a.volunteer_id = $%d)",
+			len(params))
+	}
+
+	if filter.PartnerID != 0 {
+		params = append(params, filter.PartnerID)
+		whereClause += fmt.Sprintf(" AND p.id = $%d", len(params))
+	}
+
+	if filter.CreatedFrom != nil {
+		params = append(params, *filter.CreatedFrom)
+		whereClause += fmt.Sprintf(" AND r.created_at >= $%d", len(params))
+	}
+
+	if filter.CreatedTo != nil {
+		params = append(params, *filter.CreatedTo)
+		whereClause += fmt.Sprintf(" AND r.created_at < $%d", len(params))
+	}
+
+	return whereClause, params
+}
@@ -0,0 +1,210 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// requestHandoverColumns - поля записи об отказе волонтера от заявки
+const requestHandoverColumns = `id, request_id, from_volunteer_id, to_volunteer_id, reason, status, created_at, resolved_at`
+
+// ReleaseRequest освобождает место волонтера в заявке и записывает отказ в журнал.
+// Если волонтер не закреплен за заявкой, возвращается ErrNotAssigned.
+func (r *RequestRepository) ReleaseRequest(ctx context.Context, id, volunteerID int, reason string) (*models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	released, err := releaseSlot(ctx, tx, id, volunteerID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO request_handovers (request_id, from_volunteer_id, reason, status, resolved_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`, id, volunteerID, reason, models.RequestHandoverReleased)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log request release: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request release: %w", err)
+	}
+
+	return released, nil
+}
+
+// CreateRequestHandover создает передачу заявки, ожидающую ответа волонтера.
+// Если у заявки уже есть ожидающая передача, возвращается repository.ErrConflict.
+func (r *RequestRepository) CreateRequestHandover(ctx context.Context, handover *models.RequestHandover) (*models.RequestHandover, error) {
+	query := `
+		INSERT INTO request_handovers (request_id, from_volunteer_id, to_volunteer_id, reason, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + requestHandoverColumns
+
+	var created models.RequestHandover
+	err := r.db.GetContext(ctx, &created, query,
+		handover.RequestID, handover.FromVolunteerID, handover.ToVolunteerID, handover.Reason, models.RequestHandoverPending)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to create request handover: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetPendingRequestHandover получает ожидающую ответа передачу заявки
+func (r *RequestRepository) GetPendingRequestHandover(ctx context.Context, requestID int) (*models.RequestHandover, error) {
+	var handover models.RequestHandover
+	err := r.db.GetContext(ctx, &handover, `
+		SELECT `+requestHandoverColumns+`
+		FROM request_handovers
+		WHERE request_id = $1 AND status = $2
+	`, requestID, models.RequestHandoverPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get pending request handover: %w", err)
+	}
+
+	return &handover, nil
+}
+
+// AcceptRequestHandover передает место волонтера в заявке тому, кто принял передачу.
+// Статус заявки не меняется. Если передача уже не ожидает ответа или передающий волонтер
+// больше не закреплен за заявкой, возвращается repository.ErrConflict.
+func (r *RequestRepository) AcceptRequestHandover(ctx context.Context, handoverID int) (*models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var requestID int
+	err = tx.GetContext(ctx, &requestID, `SELECT request_id FROM request_handovers WHERE id = $1`, handoverID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get request handover: %w", err)
+	}
+
+	// Заявка блокируется раньше передачи, как и при отказе волонтера, чтобы избежать взаимной блокировки
+	request, err := lockRequest(ctx, tx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var handover models.RequestHandover
+	err = tx.GetContext(ctx, &handover, `
+		UPDATE request_handovers
+		SET status = $2, resolved_at = NOW()
+		WHERE id = $1 AND status = $3
+		RETURNING `+requestHandoverColumns,
+		handoverID, models.RequestHandoverAccepted, models.RequestHandoverPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to accept request handover: %w", err)
+	}
+
+	if request.Status != models.RequestStatusNew && request.Status != models.RequestStatusInProgress {
+		return nil, repository.ErrConflict
+	}
+
+	transferred, err := transferSlot(ctx, tx, handover.RequestID, handover.FromVolunteerID, *handover.ToVolunteerID)
+	if err != nil {
+		if errors.Is(err, ErrNotAssigned) {
+			return nil, repository.ErrConflict
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request handover: %w", err)
+	}
+
+	return transferred, nil
+}
+
+// transferSlot передает место волонтера fromID в заявке волонтеру toID внутри транзакции tx.
+// Новый волонтер занимает и позицию передающего в порядке закрепления; статус заявки не меняется.
+// Если fromID не закреплен за заявкой, возвращается ErrNotAssigned,
+// если toID уже закреплен за ней, - repository.ErrConflict.
+func transferSlot(ctx context.Context, tx *sqlx.Tx, requestID, fromID, toID int) (*models.HelpRequest, error) {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE request_assignments SET volunteer_id = $3
+		WHERE request_id = $1 AND volunteer_id = $2
+	`, requestID, fromID, toID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to transfer volunteer slot: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, ErrNotAssigned
+	}
+
+	var transferred models.HelpRequest
+	err = tx.GetContext(ctx, &transferred, `
+		UPDATE help_requests
+		SET assigned_to = CASE WHEN assigned_to = $2 THEN $3 ELSE assigned_to END,
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+requestReturningColumns, requestID, fromID, toID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer request: %w", err)
+	}
+
+	return &transferred, nil
+}
+
+// DeclineRequestHandover отклоняет передачу заявки; передающий волонтер остается закрепленным.
+// Если передача уже не ожидает ответа, возвращается repository.ErrConflict.
+func (r *RequestRepository) DeclineRequestHandover(ctx context.Context, handoverID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE request_handovers
+		SET status = $2, resolved_at = NOW()
+		WHERE id = $1 AND status = $3
+	`, handoverID, models.RequestHandoverDeclined, models.RequestHandoverPending)
+	if err != nil {
+		return fmt.Errorf("failed to decline request handover: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrConflict
+	}
+
+	return nil
+}
+
+// cancelPendingHandover отменяет ожидающую передачу заявки от волонтера
+func cancelPendingHandover(ctx context.Context, tx *sqlx.Tx, requestID, volunteerID int) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE request_handovers
+		SET status = $3, resolved_at = NOW()
+		WHERE request_id = $1 AND from_volunteer_id = $2 AND status = $4
+	`, requestID, volunteerID, models.RequestHandoverCancelled, models.RequestHandoverPending)
+	if err != nil {
+		return fmt.Errorf("failed to cancel pending request handover: %w", err)
+	}
+
+	return nil
+}
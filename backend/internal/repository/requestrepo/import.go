@@ -0,0 +1,109 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+
+	"github.com/lib/pq"
+)
+
+// importedRequest - заявка, уже созданная импортом с внешним ID
+type importedRequest struct {
+	ID         int    `db:"id"`
+	ExternalID string `db:"external_id"`
+}
+
+// FindImportRequesters находит пользователей по телефонам и Telegram ID.
+// Телефоны сравниваются только по цифрам, в результате телефон тоже приведен к цифрам.
+func (r *RequestRepository) FindImportRequesters(ctx context.Context, phones, telegramIDs []string) ([]models.RequestImportRequester, error) {
+	query := `
+		SELECT id, regexp_replace(phone, '\D', '', 'g') as phone, telegram_id, role,
+			suspended_at IS NOT NULL as suspended
+		FROM users
+		WHERE is_deleted = false
+			AND (regexp_replace(phone, '\D', '', 'g') = ANY($1) OR telegram_id = ANY($2))
+	`
+
+	var requesters []models.RequestImportRequester
+	if err := r.db.SelectContext(ctx, &requesters, query, pq.Array(phones), pq.Array(telegramIDs)); err != nil {
+		return nil, fmt.Errorf("failed to find import requesters: %w", err)
+	}
+
+	return requesters, nil
+}
+
+// GetImportedRequestIDs получает ID уже импортированных заявок по их внешним ID
+func (r *RequestRepository) GetImportedRequestIDs(ctx context.Context, externalIDs []string) (map[string]int, error) {
+	var imported []importedRequest
+	err := r.db.SelectContext(ctx, &imported, `
+		SELECT id, external_id FROM help_requests WHERE external_id = ANY($1)
+	`, pq.Array(externalIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get imported requests: %w", err)
+	}
+
+	ids := make(map[string]int, len(imported))
+	for _, request := range imported {
+		ids[request.ExternalID] = request.ID
+	}
+
+	return ids, nil
+}
+
+// ImportRequests создает заявки импорта в одной транзакции и возвращает ID созданных заявок по внешним ID.
+// Заявка, внешний ID которой уже импортирован, пропускается и в результат не попадает.
+func (r *RequestRepository) ImportRequests(ctx context.Context, actorID int, items []models.RequestImportItem) (map[string]int, error) {
+	query := `
+		INSERT INTO help_requests (
+			title, description, status, category_id, priority, location, location_lat, location_lon,
+			requester_id, volunteer_slots, due_at, external_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (external_id) WHERE external_id IS NOT NULL DO NOTHING
+		RETURNING id
+	`
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	created := make(map[string]int, len(items))
+	for _, item := range items {
+		req := item.Request
+
+		var id int
+		err := tx.GetContext(ctx, &id, query,
+			req.Title, req.Description, req.Status, req.CategoryID, req.Priority, req.Location,
+			req.LocationLat, req.LocationLon, req.RequesterID, req.VolunteerSlots, req.DueAt, item.ExternalID)
+		if err != nil {
+			// Строку с тем же внешним ID успел импортировать параллельный запрос
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to import request from line %d: %w", item.Line, err)
+		}
+
+		// Автор перехода - администратор, запустивший импорт
+		err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+			RequestID: id,
+			To:        req.Status,
+			ActorID:   &actorID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		created[item.ExternalID] = id
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request import: %w", err)
+	}
+
+	return created, nil
+}
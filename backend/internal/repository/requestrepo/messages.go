@@ -0,0 +1,183 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// messageRow - строка сообщения с отправителем
+type messageRow struct {
+	models.RequestMessage
+	SenderUsername  string `db:"sender_username"`
+	SenderFirstName string `db:"sender_first_name"`
+	SenderLastName  string `db:"sender_last_name"`
+	SenderPhotoURL  string `db:"sender_photo_url"`
+}
+
+// message возвращает сообщение с заполненным отправителем
+func (row messageRow) message() models.RequestMessage {
+	message := row.RequestMessage
+	message.Sender = &models.UserShort{
+		ID:        message.SenderID,
+		Username:  row.SenderUsername,
+		FirstName: row.SenderFirstName,
+		LastName:  row.SenderLastName,
+		PhotoURL:  row.SenderPhotoURL,
+	}
+	return message
+}
+
+// GetRequestMessages возвращает до limit последних сообщений переписки с ID меньше beforeID
+// (0 - без ограничения) от старых к новым, а также есть ли более ранние сообщения.
+func (r *RequestRepository) GetRequestMessages(ctx context.Context, requestID, beforeID, limit int) ([]models.RequestMessage, bool, error) {
+	query := `
+		SELECT
+			m.id, m.request_id, m.sender_id, m.text, m.created_at,
+			COALESCE(u.username, '') as sender_username,
+			COALESCE(u.first_name, '') as sender_first_name,
+			COALESCE(u.last_name, '') as sender_last_name,
+			COALESCE(u.photo_url, '') as sender_photo_url
+		FROM request_messages m
+		LEFT JOIN users u ON m.sender_id = u.id
+		WHERE m.request_id = $1 AND ($2 = 0 OR m.id < $2)
+		ORDER BY m.id DESC
+		LIMIT $3
+	`
+
+	var rows []messageRow
+	if err := r.db.SelectContext(ctx, &rows, query, requestID, beforeID, limit+1); err != nil {
+		return nil, false, fmt.Errorf("failed to get request messages: %w", err)
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	messages := make([]models.RequestMessage, len(rows))
+	for i, row := range rows {
+		messages[len(rows)-1-i] = row.message()
+	}
+
+	return messages, hasMore, nil
+}
+
+// AddRequestMessage сохраняет сообщение и отмечает переписку прочитанной отправителем
+func (r *RequestRepository) AddRequestMessage(ctx context.Context, message *models.RequestMessage) (*models.RequestMessage, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var created models.RequestMessage
+	err = tx.GetContext(ctx, &created, `
+		INSERT INTO request_messages (request_id, sender_id, text)
+		VALUES ($1, $2, $3)
+		RETURNING id, request_id, sender_id, text, created_at
+	`, message.RequestID, message.SenderID, message.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add request message: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO request_message_reads (request_id, user_id, last_read_message_id, read_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (request_id, user_id) DO UPDATE
+		SET last_read_message_id = EXCLUDED.last_read_message_id,
+			read_at = EXCLUDED.read_at
+	`, created.RequestID, created.SenderID, created.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark own message read: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request message: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetRequestMessageReads возвращает отметки прочтения всех участников переписки
+func (r *RequestRepository) GetRequestMessageReads(ctx context.Context, requestID int) ([]models.RequestMessageRead, error) {
+	query := `
+		SELECT user_id, last_read_message_id, read_at
+		FROM request_message_reads
+		WHERE request_id = $1
+		ORDER BY user_id
+	`
+
+	reads := []models.RequestMessageRead{}
+	if err := r.db.SelectContext(ctx, &reads, query, requestID); err != nil {
+		return nil, fmt.Errorf("failed to get request message reads: %w", err)
+	}
+
+	return reads, nil
+}
+
+// MarkRequestMessagesRead отмечает переписку прочитанной пользователем до сообщения upToID
+// включительно (0 - до последнего сообщения). Отметка не сдвигается назад.
+func (r *RequestRepository) MarkRequestMessagesRead(ctx context.Context, requestID, userID, upToID int) error {
+	query := `
+		INSERT INTO request_message_reads (request_id, user_id, last_read_message_id, read_at)
+		SELECT $1, $2, MAX(id), NOW()
+		FROM request_messages
+		WHERE request_id = $1 AND ($3 = 0 OR id <= $3)
+		HAVING MAX(id) IS NOT NULL
+		ON CONFLICT (request_id, user_id) DO UPDATE
+		SET last_read_message_id = GREATEST(request_message_reads.last_read_message_id, EXCLUDED.last_read_message_id),
+			read_at = EXCLUDED.read_at
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, requestID, userID, upToID); err != nil {
+		return fmt.Errorf("failed to mark request messages read: %w", err)
+	}
+
+	return nil
+}
+
+// CountUnreadRequestMessages возвращает число сообщений других участников,
+// не прочитанных пользователем
+func (r *RequestRepository) CountUnreadRequestMessages(ctx context.Context, requestID, userID int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM request_messages m
+		LEFT JOIN request_message_reads mr ON mr.request_id = m.request_id AND mr.user_id = $2
+		WHERE m.request_id = $1 AND m.sender_id <> $2
+			AND m.id > COALESCE(mr.last_read_message_id, 0)
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, requestID, userID); err != nil {
+		return 0, fmt.Errorf("failed to count unread request messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetUnreadMessageCounts возвращает заявки, в переписке которых у пользователя
+// есть непрочитанные сообщения: как автора заявки или закрепленного волонтера
+func (r *RequestRepository) GetUnreadMessageCounts(ctx context.Context, userID int) ([]models.RequestUnreadMessages, error) {
+	query := `
+		SELECT h.id as request_id, h.title as request_title, COUNT(*) as unread_count
+		FROM request_messages m
+		INNER JOIN help_requests h ON m.request_id = h.id
+		LEFT JOIN request_message_reads mr ON mr.request_id = m.request_id AND mr.user_id = $1
+		WHERE h.is_deleted = false AND m.sender_id <> $1
+			AND m.id > COALESCE(mr.last_read_message_id, 0)
+			AND (h.requester_id = $1 OR EXISTS (
+				SELECT 1 FROM request_assignments a WHERE a.request_id = h.id AND a.volunteer_id = $1
+			))
+		GROUP BY h.id, h.title
+		ORDER BY MAX(m.id) DESC
+	`
+
+	counts := []models.RequestUnreadMessages{}
+	if err := r.db.SelectContext(ctx, &counts, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get unread message counts: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,75 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// GetRequesterHistory получает отметку проверки автора и число его выполненных и отклоненных заявок
+func (r *RequestRepository) GetRequesterHistory(ctx context.Context, userID int) (*models.RequesterHistory, error) {
+	query := `
+		SELECT
+			u.requires_review,
+			COUNT(h.id) FILTER (WHERE h.status = 'completed') as completed_requests,
+			COUNT(h.id) FILTER (WHERE h.status = 'rejected') as rejected_requests
+		FROM users u
+		LEFT JOIN help_requests h ON h.requester_id = u.id AND h.is_deleted = false
+		WHERE u.id = $1
+		GROUP BY u.id
+	`
+
+	var history models.RequesterHistory
+	if err := r.db.GetContext(ctx, &history, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get requester history: %w", err)
+	}
+
+	return &history, nil
+}
+
+// SetUserRequiresReview отмечает пользователя, все заявки которого проходят проверку, или снимает отметку
+func (r *RequestRepository) SetUserRequiresReview(ctx context.Context, userID int, requiresReview bool) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET requires_review = $2, updated_at = NOW()
+		WHERE id = $1
+	`, userID, requiresReview)
+	if err != nil {
+		return fmt.Errorf("failed to set user review flag: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetModerationQueue получает страницу заявок, ожидающих проверки, начиная с самых давних,
+// и общее количество таких заявок
+func (r *RequestRepository) GetModerationQueue(ctx context.Context, limit, offset int) ([]models.RequestFullInfo, int, error) {
+	whereClause := `WHERE r.is_deleted = false AND r.status = 'pending_review'`
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", requestFullInfoFrom, whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery); err != nil {
+		return nil, 0, fmt.Errorf("failed to count requests pending review: %w", err)
+	}
+
+	query := fmt.Sprintf(`%s %s %s
+		ORDER BY r.created_at, r.id
+		LIMIT $1 OFFSET $2`,
+		requestFullInfoSelect, requestFullInfoFrom, whereClause)
+
+	var requests []models.RequestFullInfo
+	if err := r.db.SelectContext(ctx, &requests, query, limit, offset); err != nil {
+		return nil, 0, fmt.Errorf("failed to get requests pending review: %w", err)
+	}
+
+	return requests, total, nil
+}
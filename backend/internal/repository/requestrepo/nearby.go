@@ -0,0 +1,76 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// kmPerLatDegree - длина одного градуса широты в километрах
+const kmPerLatDegree = 111.045
+
+// haversineDistanceSQL вычисляет расстояние в километрах от точки ($1, $2) до заявки.
+// 6371 - средний радиус Земли в километрах.
+const haversineDistanceSQL = `
+	(2 * 6371.0 * ASIN(LEAST(1, SQRT(
+		POWER(SIN(RADIANS(r.location_lat - $1) / 2), 2) +
+		COS(RADIANS($1)) * COS(RADIANS(r.location_lat)) *
+		POWER(SIN(RADIANS(r.location_lon - $2) / 2), 2)
+	))))`
+
+// GetNearbyRequests возвращает заявки в радиусе filter.RadiusKm от точки,
+// отсортированные по расстоянию, и общее количество найденных заявок.
+// Если статус не указан, возвращаются только открытые заявки.
+func (r *RequestRepository) GetNearbyRequests(ctx context.Context, filter models.NearbyRequestFilter) ([]models.RequestFullInfo, int, error) {
+	if filter.Status == "" {
+		filter.Status = string(models.RequestStatusNew)
+	}
+
+	params := []interface{}{filter.Lat, filter.Lon}
+	whereClause, params := buildRequestFilter(filter.RequestFilter, params)
+	whereClause += " AND r.location_lat IS NOT NULL AND r.location_lon IS NOT NULL"
+
+	// Ограничивающий прямоугольник отсекает далекие заявки до вычисления расстояния
+	latDelta := filter.RadiusKm / kmPerLatDegree
+	params = append(params, filter.Lat-latDelta, filter.Lat+latDelta)
+	whereClause += fmt.Sprintf(" AND r.location_lat BETWEEN $%d AND $%d", len(params)-1, len(params))
+
+	// Около полюсов и линии перемены дат прямоугольник по долготе не строится
+	cosLat := math.Cos(filter.Lat * math.Pi / 180)
+	if cosLat > 0.01 {
+		lonDelta := latDelta / cosLat
+		if filter.Lon-lonDelta >= -180 && filter.Lon+lonDelta <= 180 {
+			params = append(params, filter.Lon-lonDelta, filter.Lon+lonDelta)
+			whereClause += fmt.Sprintf(" AND r.location_lon BETWEEN $%d AND $%d", len(params)-1, len(params))
+		}
+	}
+
+	params = append(params, filter.RadiusKm)
+	whereClause += fmt.Sprintf(" AND %s <= $%d", haversineDistanceSQL, len(params))
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", requestFullInfoFrom, whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count nearby requests: %w", err)
+	}
+
+	query := fmt.Sprintf(`%s, %s as distance_km %s %s
+		ORDER BY distance_km, r.created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		requestFullInfoSelect, haversineDistanceSQL, requestFullInfoFrom, whereClause,
+		len(params)+1, len(params)+2)
+	params = append(params, filter.Limit, filter.Offset)
+
+	var requests []models.RequestFullInfo
+	if err := r.db.SelectContext(ctx, &requests, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to get nearby requests: %w", err)
+	}
+
+	if err := r.attachVolunteers(ctx, requests); err != nil {
+		return nil, 0, err
+	}
+
+	return requests, total, nil
+}
@@ -0,0 +1,136 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// reviewRow - оценка вместе с автором оценки и названием заявки
+type reviewRow struct {
+	models.RequestRating
+	RequestTitle   string `db:"request_title"`
+	RaterUsername  string `db:"rater_username"`
+	RaterFirstName string `db:"rater_first_name"`
+	RaterLastName  string `db:"rater_last_name"`
+	RaterPhotoURL  string `db:"rater_photo_url"`
+}
+
+// AddRating сохраняет оценку и пересчитывает рейтинг оцененного пользователя в одной транзакции.
+// К оценкам пользователя добавляется priorWeight оценок, равных priorMean.
+// Если пользователь уже оценил этого участника заявки, возвращается repository.ErrConflict.
+func (r *RequestRepository) AddRating(ctx context.Context, rating *models.RequestRating, priorMean, priorWeight float64) (*models.RequestRating, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка оцененного пользователя: одновременные оценки пересчитывают рейтинг по очереди
+	// и не перезаписывают его значением, посчитанным без чужой оценки
+	var locked int
+	err = tx.GetContext(ctx, &locked, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, rating.RatedID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to lock rated user: %w", err)
+	}
+
+	var created models.RequestRating
+	err = tx.GetContext(ctx, &created, `
+		INSERT INTO request_ratings (request_id, rater_id, rated_id, rating, comment)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		ON CONFLICT (request_id, rater_id, rated_id) DO NOTHING
+		RETURNING id, request_id, rater_id, rated_id, rating, COALESCE(comment, '') as comment, created_at
+	`, rating.RequestID, rating.RaterID, rating.RatedID, rating.Rating, rating.Comment)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to add rating: %w", err)
+	}
+
+	// Рейтинг пересчитывается целиком, чтобы не накапливать ошибку округления.
+	// Априорное среднее задано настройкой, а не средней по всем оценкам: иначе рейтинги,
+	// пересчитанные в разное время, были бы несравнимы между собой
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO user_stats (user_id, rating, ratings_count)
+		SELECT $1, ROUND(($2::numeric * $3::numeric + u.total) / ($2::numeric + u.count), 2), u.count
+		FROM (SELECT SUM(rating) as total, COUNT(*) as count FROM request_ratings WHERE rated_id = $1) u
+		ON CONFLICT (user_id) DO UPDATE
+		SET rating = EXCLUDED.rating,
+			ratings_count = EXCLUDED.ratings_count,
+			updated_at = NOW()
+	`, rating.RatedID, priorWeight, priorMean)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recalculate user rating: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit rating: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetUserReviews возвращает рейтинг пользователя и страницу полученных им отзывов, новые первыми
+func (r *RequestRepository) GetUserReviews(ctx context.Context, userID, limit, offset int) (*models.UserReviews, error) {
+	reviews := &models.UserReviews{
+		UserID:  userID,
+		Reviews: []models.RequestRating{},
+	}
+
+	err := r.db.QueryRowxContext(ctx, `
+		SELECT
+			COALESCE((SELECT rating FROM user_stats WHERE user_id = $1), 0),
+			COUNT(*),
+			COALESCE(ROUND(AVG(rating), 2), 0)
+		FROM request_ratings
+		WHERE rated_id = $1
+	`, userID).Scan(&reviews.Rating, &reviews.RatingsCount, &reviews.AverageRating)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user rating: %w", err)
+	}
+	reviews.Total = reviews.RatingsCount
+
+	var rows []reviewRow
+	err = r.db.SelectContext(ctx, &rows, `
+		SELECT
+			rr.id, rr.request_id, rr.rater_id, rr.rated_id, rr.rating,
+			COALESCE(rr.comment, '') as comment, rr.created_at,
+			h.title as request_title,
+			COALESCE(u.username, '') as rater_username,
+			COALESCE(u.first_name, '') as rater_first_name,
+			COALESCE(u.last_name, '') as rater_last_name,
+			COALESCE(u.photo_url, '') as rater_photo_url
+		FROM request_ratings rr
+		INNER JOIN help_requests h ON rr.request_id = h.id
+		INNER JOIN users u ON rr.rater_id = u.id
+		WHERE rr.rated_id = $1
+		ORDER BY rr.created_at DESC, rr.id DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user reviews: %w", err)
+	}
+
+	for _, row := range rows {
+		review := row.RequestRating
+		review.RequestTitle = row.RequestTitle
+		review.Rater = &models.UserShort{
+			ID:        review.RaterID,
+			Username:  row.RaterUsername,
+			FirstName: row.RaterFirstName,
+			LastName:  row.RaterLastName,
+			PhotoURL:  row.RaterPhotoURL,
+		}
+		reviews.Reviews = append(reviews.Reviews, review)
+	}
+
+	return reviews, nil
+}
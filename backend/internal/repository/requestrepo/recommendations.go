@@ -0,0 +1,109 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// categoryCount - число выполненных волонтером заявок в категории
+type categoryCount struct {
+	CategoryID int `db:"category_id"`
+	Count      int `db:"count"`
+}
+
+// GetRecommendationCandidates получает не более limit открытых заявок со свободными местами,
+// за которыми волонтер еще не закреплен и авторы которых не внесли его в черный список, начиная с самых давних
+func (r *RequestRepository) GetRecommendationCandidates(ctx context.Context, volunteerID, limit int) ([]models.RecommendationCandidate, error) {
+	query := requestFullInfoSelect + `, r.category_id` + requestFullInfoFrom + `
+		WHERE r.status = 'new' AND r.is_deleted = false AND r.hidden_at IS NULL
+			AND r.requester_id <> $1
+			AND NOT EXISTS (
+				SELECT 1 FROM user_blocks b
+				WHERE b.blocker_id = r.requester_id AND b.blocked_id = $1
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM request_assignments a
+				WHERE a.request_id = r.id AND a.volunteer_id = $1
+			)
+			AND (SELECT COUNT(*) FROM request_assignments a WHERE a.request_id = r.id) < r.volunteer_slots
+		ORDER BY r.created_at, r.id
+		LIMIT $2
+	`
+
+	var candidates []models.RecommendationCandidate
+	if err := r.db.SelectContext(ctx, &candidates, query, volunteerID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get recommendation candidates: %w", err)
+	}
+
+	ids := make([]int, len(candidates))
+	for i := range candidates {
+		ids[i] = candidates[i].ID
+	}
+
+	volunteers, err := r.getVolunteersByRequest(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		candidates[i].Volunteers = volunteers[candidates[i].ID]
+	}
+
+	return candidates, nil
+}
+
+// GetVolunteerProfile получает домашнюю точку, рейтинг волонтера и число выполненных им заявок по категориям
+func (r *RequestRepository) GetVolunteerProfile(ctx context.Context, volunteerID int) (*models.VolunteerProfile, error) {
+	var profile models.VolunteerProfile
+	err := r.db.GetContext(ctx, &profile, `
+		SELECT u.home_lat, u.home_lon, COALESCE(us.rating, 0) as rating
+		FROM users u
+		LEFT JOIN user_stats us ON us.user_id = u.id
+		WHERE u.id = $1
+	`, volunteerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get volunteer profile: %w", err)
+	}
+
+	var counts []categoryCount
+	err = r.db.SelectContext(ctx, &counts, `
+		SELECT h.category_id, COUNT(*) as count
+		FROM request_assignments a
+		INNER JOIN help_requests h ON a.request_id = h.id
+		WHERE a.volunteer_id = $1 AND h.status = 'completed' AND h.is_deleted = false
+		GROUP BY h.category_id
+	`, volunteerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get volunteer category history: %w", err)
+	}
+
+	profile.CompletedByCategory = make(map[int]int, len(counts))
+	for _, c := range counts {
+		profile.CompletedByCategory[c.CategoryID] = c.Count
+	}
+
+	return &profile, nil
+}
+
+// SetHomeLocation сохраняет домашнюю точку пользователя; nil-координаты удаляют ее
+func (r *RequestRepository) SetHomeLocation(ctx context.Context, userID int, lat, lon *float64) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET home_lat = $2, home_lon = $3, updated_at = NOW()
+		WHERE id = $1
+	`, userID, lat, lon)
+	if err != nil {
+		return fmt.Errorf("failed to set home location: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// requestReportGroupSQL возвращает SQL-выражение ключа группы отчета
+func requestReportGroupSQL(groupBy models.RequestReportGroupBy) string {
+	switch groupBy {
+	case models.RequestReportByCategory:
+		return "category_name"
+	case models.RequestReportByPriority:
+		return "priority::text"
+	case models.RequestReportByWeek:
+		return "to_char(date_trunc('week', created_at), 'YYYY-MM-DD')"
+	default:
+		return "to_char(date_trunc('month', created_at), 'YYYY-MM')"
+	}
+}
+
+// GetRequestReport возвращает показатели заявок, созданных в периоде, по группам
+// и итоговую строку по всем заявкам. Время до назначения отсчитывается от публикации
+// (первого перехода в "new", без него - от создания) до закрепления первого волонтера.
+// Момент выполнения берется из истории статусов; для заявок без истории - из completed_at.
+func (r *RequestRepository) GetRequestReport(ctx context.Context, filter models.RequestReportFilter) ([]models.RequestReportRow, *models.RequestReportRow, error) {
+	query := fmt.Sprintf(`
+		WITH scope AS (
+			SELECT
+				r.status, r.priority, COALESCE(c.name, '') as category_name, r.created_at,
+				COALESCE(published.at, r.created_at) as published_at,
+				LEAST(assigned.at, started.at) as assigned_at,
+				CASE WHEN r.status = 'completed' THEN COALESCE(completed.at, r.completed_at) END as completed_at
+			FROM help_requests r
+			LEFT JOIN request_categories c ON r.category_id = c.id
+			LEFT JOIN LATERAL (
+				SELECT MIN(h.created_at) as at FROM request_status_history h
+				WHERE h.request_id = r.id AND h.to_status = 'new'
+			) published ON true
+			-- Заявка с несколькими местами переходит в работу, только когда заняты все места,
+			-- поэтому назначение - это закрепление первого волонтера. Места освобождаются
+			-- с удалением строки, поэтому учитывается и первый переход в работу
+			LEFT JOIN LATERAL (
+				SELECT MIN(a.created_at) as at FROM request_assignments a
+				WHERE a.request_id = r.id
+			) assigned ON true
+			LEFT JOIN LATERAL (
+				SELECT MIN(h.created_at) as at FROM request_status_history h
+				WHERE h.request_id = r.id AND h.to_status = 'in_progress'
+			) started ON true
+			LEFT JOIN LATERAL (
+				SELECT MIN(h.created_at) as at FROM request_status_history h
+				WHERE h.request_id = r.id AND h.to_status = 'completed'
+			) completed ON true
+			WHERE r.is_deleted = false
+				AND r.status NOT IN ('pending_review', 'changes_requested', 'rejected')
+				AND r.created_at >= $1 AND r.created_at < $2
+		)
+		SELECT
+			COALESCE(%s, '') as group_key,
+			GROUPING(%s) = 1 as is_summary,
+			COUNT(*) as total,
+			COUNT(assigned_at) as assigned,
+			COUNT(*) FILTER (WHERE status = 'completed') as completed,
+			COUNT(*) FILTER (WHERE status = 'cancelled') as cancelled,
+			COUNT(*) FILTER (WHERE status = 'expired') as expired,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM assigned_at - published_at) / 60) as median_assign_minutes,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM assigned_at - published_at) / 60) as p90_assign_minutes,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM completed_at - assigned_at) / 60) as median_complete_minutes,
+			percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM completed_at - assigned_at) / 60) as p90_complete_minutes
+		FROM scope
+		GROUP BY GROUPING SETS ((%s), ())
+		ORDER BY is_summary, group_key
+	`, requestReportGroupSQL(filter.GroupBy), requestReportGroupSQL(filter.GroupBy), requestReportGroupSQL(filter.GroupBy))
+
+	var rows []struct {
+		models.RequestReportRow
+		IsSummary bool `db:"is_summary"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, filter.From, filter.To); err != nil {
+		return nil, nil, fmt.Errorf("failed to get request report: %w", err)
+	}
+
+	groups := make([]models.RequestReportRow, 0, len(rows))
+	summary := &models.RequestReportRow{}
+	for _, row := range rows {
+		if row.IsSummary {
+			*summary = row.RequestReportRow
+			continue
+		}
+		groups = append(groups, row.RequestReportRow)
+	}
+
+	return groups, summary, nil
+}
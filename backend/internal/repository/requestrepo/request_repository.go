@@ -19,6 +19,9 @@ var (
 	ErrRequestNotFound  = errors.New("request not found")
 	ErrUserNotFound     = errors.New("user not found")
 	ErrCategoryNotFound = errors.New("category not found")
+	ErrNotAssigned      = errors.New("volunteer is not assigned to request")
+	ErrVolunteerBusy    = errors.New("volunteer has too many active requests")
+	ErrNotHideable      = errors.New("only requests and comments can be hidden")
 )
 
 // RequestRepository представляет репозиторий для работы с запросами на помощь
@@ -40,28 +43,50 @@ func (r *RequestRepository) CreateRequest(ctx context.Context, req *models.HelpR
 	query := `
 		INSERT INTO help_requests (
 			title, description, status, category_id, priority, location_address, location_lat, location_lon, 
-			requester_id, is_deleted
+			requester_id, volunteer_slots, due_at, partner_id, is_deleted
 		) VALUES (
 			:title, :description, :status, :category_id, :priority, :location_address, :location_lat, :location_lon, 
-			:requester_id, :is_deleted
+			:requester_id, :volunteer_slots, :due_at, :partner_id, :is_deleted
 		) RETURNING id, title, description, status, category_id, priority, location_address, location_lat, location_lon,
-			requester_id, assigned_user_id, is_deleted, created_at, updated_at, completed_at
+			requester_id, assigned_user_id, volunteer_slots, due_at, partner_id, is_deleted, created_at, updated_at, completed_at
 	`
 
-	rows, err := r.db.NamedQueryContext(ctx, query, req)
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := sqlx.NamedQueryContext(ctx, tx, query, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
-	defer rows.Close()
 
 	if !rows.Next() {
+		rows.Close()
 		return nil, errors.New("no rows returned after request creation")
 	}
 
 	var createdRequest models.HelpRequest
 	if err := rows.StructScan(&createdRequest); err != nil {
+		rows.Close()
 		return nil, fmt.Errorf("failed to scan created request: %w", err)
 	}
+	rows.Close()
+
+	// Первая запись истории статусов: заявка создана автором
+	err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+		RequestID: createdRequest.ID,
+		To:        createdRequest.Status,
+		ActorID:   &createdRequest.RequesterID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit request creation: %w", err)
+	}
 
 	return &createdRequest, nil
 }
@@ -71,7 +96,8 @@ func (r *RequestRepository) GetRequestByID(ctx context.Context, id int) (*models
 	var request models.HelpRequest
 	query := `
 		SELECT id, title, description, status, category_id, priority, location_address, location_lat, location_lon,
-			requester_id, assigned_user_id, is_deleted, created_at, updated_at, completed_at
+			requester_id, assigned_user_id, volunteer_slots, due_at, is_deleted, created_at, updated_at, completed_at,
+			hidden_at IS NOT NULL as hidden
 		FROM help_requests
 		WHERE id = $1 AND is_deleted = false
 	`
@@ -87,7 +113,8 @@ func (r *RequestRepository) GetRequestByID(ctx context.Context, id int) (*models
 	return &request, nil
 }
 
-// GetRequestFullInfo получает полную информацию о запросе
+// GetRequestFullInfo получает полную информацию о запросе.
+// Комментарии не встраиваются: их ветки отдаются постранично через GetRequestComments.
 func (r *RequestRepository) GetRequestFullInfo(ctx context.Context, id int) (*models.RequestFullInfo, error) {
 	query := `
 		SELECT 
@@ -98,11 +125,14 @@ func (r *RequestRepository) GetRequestFullInfo(ctx context.Context, id int) (*mo
 			u1.last_name as requester_last_name, u1.photo_url as requester_photo_url,
 			r.assigned_user_id, u2.username as volunteer_username, u2.first_name as volunteer_first_name, 
 			u2.last_name as volunteer_last_name, u2.photo_url as volunteer_photo_url,
-			r.is_deleted, r.created_at, r.updated_at, r.completed_at
+			r.volunteer_slots, r.due_at, r.is_deleted, r.created_at, r.updated_at, r.completed_at,
+			r.hidden_at IS NOT NULL as hidden,
+			p.id as partner_id, p.name as partner_name
 		FROM help_requests r
 		LEFT JOIN users u1 ON r.requester_id = u1.id
 		LEFT JOIN users u2 ON r.assigned_user_id = u2.id
 		LEFT JOIN request_categories c ON r.category_id = c.id
+		LEFT JOIN partner_organizations p ON r.partner_id = p.id AND p.is_verified
 		WHERE r.id = $1 AND r.is_deleted = false
 	`
 
@@ -115,20 +145,9 @@ func (r *RequestRepository) GetRequestFullInfo(ctx context.Context, id int) (*mo
 		return nil, fmt.Errorf("failed to get request full info: %w", err)
 	}
 
-	// Получаем комментарии к запросу
-	commentsQuery := `
-		SELECT 
-			c.id, c.request_id, c.user_id, u.username, u.first_name, u.last_name, u.photo_url,
-			c.content, c.created_at
-		FROM request_comments c
-		INNER JOIN users u ON c.user_id = u.id
-		WHERE c.request_id = $1
-		ORDER BY c.created_at
-	`
-
-	err = r.db.SelectContext(ctx, &requestInfo.Comments, commentsQuery, id)
+	requestInfo.Volunteers, err = r.GetRequestVolunteers(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get request comments: %w", err)
+		return nil, err
 	}
 
 	// Получаем оценки запроса
@@ -153,71 +172,60 @@ func (r *RequestRepository) GetRequestFullInfo(ctx context.Context, id int) (*mo
 	return &requestInfo, nil
 }
 
-// GetRequests получает список запросов с фильтрацией
-func (r *RequestRepository) GetRequests(ctx context.Context, userID *int, status string, categoryID *int, limit, offset int) ([]models.RequestFullInfo, error) {
-	whereClause := "WHERE r.is_deleted = false"
-	var params []interface{}
-	paramCount := 1
+// GetRequests получает страницу ленты заявок с фильтрацией.
+// Если в фильтре задан курсор, страница начинается сразу после него,
+// иначе используется смещение filter.Offset.
+func (r *RequestRepository) GetRequests(ctx context.Context, filter models.RequestFilter) (*models.RequestList, error) {
+	whereClause, params := buildRequestFilter(filter, nil)
 
-	if userID != nil {
-		whereClause += fmt.Sprintf(" AND (r.requester_id = $%d OR r.assigned_user_id = $%d)", paramCount, paramCount)
-		params = append(params, *userID)
-		paramCount++
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", requestFullInfoFrom, whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, fmt.Errorf("failed to count requests: %w", err)
 	}
 
-	if status != "" {
-		whereClause += fmt.Sprintf(" AND r.status = $%d", paramCount)
-		params = append(params, status)
-		paramCount++
-	}
+	offset := filter.Offset
+	if filter.Cursor != "" {
+		cursor, err := decodeRequestCursor(filter.Cursor, filter.Sort)
+		if err != nil {
+			return nil, err
+		}
 
-	if categoryID != nil {
-		whereClause += fmt.Sprintf(" AND r.category_id = $%d", paramCount)
-		params = append(params, *categoryID)
-		paramCount++
+		var condition string
+		condition, params = buildRequestCursorCondition(cursor, params)
+		whereClause += condition
+		offset = 0
 	}
 
-	query := fmt.Sprintf(`
-		SELECT 
-			r.id, r.title, r.description, r.status, r.category_id, c.name as category_name, 
-			c.description as category_description, c.icon as category_icon, c.color as category_color,
-			r.priority, r.location_address, r.location_lat, r.location_lon,
-			r.requester_id, u1.username as requester_username, u1.first_name as requester_first_name, 
-			u1.last_name as requester_last_name, u1.photo_url as requester_photo_url,
-			r.assigned_user_id, u2.username as volunteer_username, u2.first_name as volunteer_first_name, 
-			u2.last_name as volunteer_last_name, u2.photo_url as volunteer_photo_url,
-			r.is_deleted, r.created_at, r.updated_at, r.completed_at
-		FROM help_requests r
-		LEFT JOIN users u1 ON r.requester_id = u1.id
-		LEFT JOIN users u2 ON r.assigned_user_id = u2.id
-		LEFT JOIN request_categories c ON r.category_id = c.id
-		%s
-		ORDER BY 
-			CASE WHEN r.status = 'new' THEN 1
-				 WHEN r.status = 'in_progress' THEN 2
-				 WHEN r.status = 'completed' THEN 3
-				 ELSE 4
-			END,
-			CASE WHEN r.priority = 'high' THEN 1
-				 WHEN r.priority = 'medium' THEN 2
-				 ELSE 3
-			END,
-			r.created_at DESC
-		LIMIT $%d OFFSET $%d
-	`, whereClause, paramCount, paramCount+1)
-
-	params = append(params, limit, offset)
+	// Запрашиваем на одну заявку больше, чтобы узнать, есть ли следующая страница
+	query := fmt.Sprintf(`%s %s %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`,
+		requestFullInfoSelect, requestFullInfoFrom, whereClause, requestOrder(filter.Sort),
+		len(params)+1, len(params)+2)
+	params = append(params, filter.Limit+1, offset)
 
 	var requests []models.RequestFullInfo
-	err := r.db.SelectContext(ctx, &requests, query, params...)
-	if err != nil {
+	if err := r.db.SelectContext(ctx, &requests, query, params...); err != nil {
 		return nil, fmt.Errorf("failed to get requests: %w", err)
 	}
 
-	return requests, nil
+	list := &models.RequestList{Items: requests, Total: total}
+	if len(requests) > filter.Limit {
+		list.Items = requests[:filter.Limit]
+		list.NextCursor = encodeRequestCursor(list.Items[len(list.Items)-1], filter.Sort)
+	}
+
+	if err := r.attachVolunteers(ctx, list.Items); err != nil {
+		return nil, err
+	}
+
+	return list, nil
 }
 
-// UpdateRequest обновляет запрос
+// UpdateRequest обновляет запрос.
+// Статус запроса здесь не меняется: для этого используется ChangeRequestStatus,
+// который записывает переход в историю.
 func (r *RequestRepository) UpdateRequest(ctx context.Context, id int, update *models.RequestUpdateInput) (*models.HelpRequest, error) {
 	request, err := r.GetRequestByID(ctx, id)
 	if err != nil {
@@ -231,14 +239,6 @@ func (r *RequestRepository) UpdateRequest(ctx context.Context, id int, update *m
 	if update.Description != nil {
 		request.Description = *update.Description
 	}
-	if update.Status != nil {
-		request.Status = *update.Status
-		// Если статус изменился на "completed", обновляем время завершения
-		if *update.Status == string(models.RequestStatusCompleted) {
-			now := time.Now()
-			request.CompletedAt = &now
-		}
-	}
 	if update.CategoryID != nil {
 		request.CategoryID = *update.CategoryID
 	}
@@ -257,6 +257,12 @@ func (r *RequestRepository) UpdateRequest(ctx context.Context, id int, update *m
 	if update.AssignedUserID != nil {
 		request.AssignedUserID = update.AssignedUserID
 	}
+	if update.VolunteerSlots != nil {
+		request.VolunteerSlots = *update.VolunteerSlots
+	}
+	if update.DueAt != nil {
+		request.DueAt = update.DueAt
+	}
 
 	// Обновляем время изменения
 	request.UpdatedAt = time.Now()
@@ -265,18 +271,18 @@ func (r *RequestRepository) UpdateRequest(ctx context.Context, id int, update *m
 		UPDATE help_requests
 		SET title = :title,
 			description = :description,
-			status = :status,
 			category_id = :category_id,
 			priority = :priority,
 			location_address = :location_address,
 			location_lat = :location_lat,
 			location_lon = :location_lon,
 			assigned_user_id = :assigned_user_id,
-			updated_at = :updated_at,
-			completed_at = :completed_at
+			volunteer_slots = :volunteer_slots,
+			due_at = :due_at,
+			updated_at = :updated_at
 		WHERE id = :id AND is_deleted = false
 		RETURNING id, title, description, status, category_id, priority, location_address, location_lat, location_lon,
-			requester_id, assigned_user_id, is_deleted, created_at, updated_at, completed_at
+			requester_id, assigned_user_id, volunteer_slots, due_at, is_deleted, created_at, updated_at, completed_at
 	`
 
 	rows, err := r.db.NamedQueryContext(ctx, query, request)
@@ -322,116 +328,6 @@ func (r *RequestRepository) DeleteRequest(ctx context.Context, id int) error {
 	return nil
 }
 
-// AddComment добавляет комментарий к запросу
-func (r *RequestRepository) AddComment(ctx context.Context, comment *models.RequestComment) (*models.RequestComment, error) {
-	query := `
-		INSERT INTO request_comments (request_id, user_id, content)
-		VALUES (:request_id, :user_id, :content)
-		RETURNING id, request_id, user_id, content, created_at
-	`
-
-	rows, err := r.db.NamedQueryContext(ctx, query, comment)
-	if err != nil {
-		return nil, fmt.Errorf("failed to add comment: %w", err)
-	}
-	defer rows.Close()
-
-	if !rows.Next() {
-		return nil, errors.New("no rows returned after comment creation")
-	}
-
-	var createdComment models.RequestComment
-	if err := rows.StructScan(&createdComment); err != nil {
-		return nil, fmt.Errorf("failed to scan created comment: %w", err)
-	}
-
-	// Получаем информацию о пользователе
-	userQuery := `
-		SELECT username, first_name, last_name, photo_url
-		FROM users
-		WHERE id = $1
-	`
-
-	var user models.UserShort
-	err = r.db.GetContext(ctx, &user, userQuery, comment.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user info: %w", err)
-	}
-
-	createdComment.User = &user
-
-	return &createdComment, nil
-}
-
-// AddRating добавляет оценку к запросу
-func (r *RequestRepository) AddRating(ctx context.Context, rating *models.RequestRating) (*models.RequestRating, error) {
-	// Проверяем наличие запроса
-	_, err := r.GetRequestByID(ctx, rating.RequestID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Проверяем, не ставил ли пользователь уже оценку
-	existingQuery := `
-		SELECT id FROM request_ratings
-		WHERE request_id = $1 AND rater_id = $2 AND rated_user_id = $3
-	`
-
-	var existingID int
-	err = r.db.GetContext(ctx, &existingID, existingQuery, rating.RequestID, rating.RaterID, rating.RatedUserID)
-	if err == nil {
-		return nil, repository.ErrConflict
-	} else if !errors.Is(err, sql.ErrNoRows) {
-		return nil, fmt.Errorf("failed to check existing rating: %w", err)
-	}
-
-	// Добавляем оценку
-	query := `
-		INSERT INTO request_ratings (request_id, rater_id, rated_user_id, rating, comment)
-		VALUES (:request_id, :rater_id, :rated_user_id, :rating, :comment)
-		RETURNING id, request_id, rater_id, rated_user_id, rating, comment, created_at
-	`
-
-	rows, err := r.db.NamedQueryContext(ctx, query, rating)
-	if err != nil {
-		return nil, fmt.Errorf("failed to add rating: %w", err)
-	}
-	defer rows.Close()
-
-	if !rows.Next() {
-		return nil, errors.New("no rows returned after rating creation")
-	}
-
-	var createdRating models.RequestRating
-	if err := rows.StructScan(&createdRating); err != nil {
-		return nil, fmt.Errorf("failed to scan created rating: %w", err)
-	}
-
-	// Получаем информацию о пользователях
-	raterQuery := `
-		SELECT username, first_name, last_name, photo_url
-		FROM users
-		WHERE id = $1
-	`
-
-	var rater models.UserShort
-	err = r.db.GetContext(ctx, &rater, raterQuery, rating.RaterID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rater info: %w", err)
-	}
-
-	var rated models.UserShort
-	err = r.db.GetContext(ctx, &rated, raterQuery, rating.RatedUserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rated user info: %w", err)
-	}
-
-	createdRating.Rater = &rater
-	createdRating.RatedUser = &rated
-
-	return &createdRating, nil
-}
-
 // GetCategories получает список категорий запросов
 func (r *RequestRepository) GetCategories(ctx context.Context) ([]models.RequestCategory, error) {
 	query := `
@@ -448,22 +344,3 @@ func (r *RequestRepository) GetCategories(ctx context.Context) ([]models.Request
 
 	return categories, nil
 }
-
-// GetStats получает статистику запросов
-func (r *RequestRepository) GetStats(ctx context.Context) (*models.RequestStats, error) {
-	query := `
-		SELECT 
-			(SELECT COUNT(*) FROM help_requests WHERE is_deleted = false) as total_requests,
-			(SELECT COUNT(*) FROM help_requests WHERE status = 'completed' AND is_deleted = false) as completed_requests,
-			(SELECT COUNT(*) FROM users WHERE role = 'volunteer') as total_volunteers,
-			(SELECT AVG(rating) FROM request_ratings) as avg_rating
-	`
-
-	var stats models.RequestStats
-	err := r.db.GetContext(ctx, &stats, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get stats: %w", err)
-	}
-
-	return &stats, nil
-}
@@ -0,0 +1,86 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// Границы совпадений во фрагментах ts_headline. ts_headline не экранирует текст заявки,
+// поэтому совпадения отмечаются символами из области частного использования Unicode,
+// а теги <mark> расставляются после экранирования в highlightSearchMatches
+const (
+	searchMatchStart = "\uE000"
+	searchMatchStop  = "\uE001"
+)
+
+// searchTitleHeadlineOptions - параметры ts_headline для заголовка
+const searchTitleHeadlineOptions = `HighlightAll=true, StartSel="` + searchMatchStart + `", StopSel="` + searchMatchStop + `"`
+
+// searchHeadlineOptions - параметры ts_headline для фрагментов описания
+const searchHeadlineOptions = `StartSel="` + searchMatchStart + `", StopSel="` + searchMatchStop +
+	`", MaxFragments=2, MinWords=10, MaxWords=30, FragmentDelimiter=" ... "`
+
+// searchMatchReplacer заменяет границы совпадений тегами <mark>
+var searchMatchReplacer = strings.NewReplacer(searchMatchStart, "<mark>", searchMatchStop, "</mark>")
+
+// highlightSearchMatches экранирует фрагмент ts_headline и выделяет совпадения тегами <mark>
+func highlightSearchMatches(headline string) string {
+	return searchMatchReplacer.Replace(html.EscapeString(headline))
+}
+
+// SearchRequests выполняет полнотекстовый поиск по заголовку, описанию и адресу заявок.
+// Результаты отсортированы по релевантности и содержат фрагменты с выделенными совпадениями.
+func (r *RequestRepository) SearchRequests(ctx context.Context, filter models.RequestSearchFilter) ([]models.RequestSearchResult, int, error) {
+	params := []interface{}{filter.Query}
+	whereClause, params := buildRequestFilter(filter.RequestFilter, params)
+	whereClause += " AND r.fts_document @@ search_query"
+
+	from := requestFullInfoFrom + `
+	CROSS JOIN plainto_tsquery('russian', $1) AS search_query`
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", from, whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to count search results: %w", err)
+	}
+
+	query := fmt.Sprintf(`%s,
+		ts_rank(r.fts_document, search_query) as rank,
+		ts_headline('russian', r.title, search_query, '%s') as title_highlight,
+		ts_headline('russian', COALESCE(r.description, ''), search_query, '%s') as snippet
+		%s %s
+		ORDER BY rank DESC, r.created_at DESC
+		LIMIT $%d OFFSET $%d`,
+		requestFullInfoSelect, searchTitleHeadlineOptions, searchHeadlineOptions, from, whereClause,
+		len(params)+1, len(params)+2)
+	params = append(params, filter.Limit, filter.Offset)
+
+	var results []models.RequestSearchResult
+	if err := r.db.SelectContext(ctx, &results, query, params...); err != nil {
+		return nil, 0, fmt.Errorf("failed to search requests: %w", err)
+	}
+
+	if len(results) > 0 {
+		ids := make([]int, len(results))
+		for i := range results {
+			ids[i] = results[i].ID
+		}
+
+		volunteers, err := r.getVolunteersByRequest(ctx, ids)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for i := range results {
+			results[i].Volunteers = volunteers[results[i].ID]
+			results[i].TitleHighlight = highlightSearchMatches(results[i].TitleHighlight)
+			results[i].Snippet = highlightSearchMatches(results[i].Snippet)
+		}
+	}
+
+	return results, total, nil
+}
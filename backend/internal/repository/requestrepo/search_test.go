@@ -0,0 +1,40 @@
+package requestrepo
+
+import "testing"
+
+func TestHighlightSearchMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		headline string
+		want     string
+	}{
+		{
+			name:     "plain text",
+			headline: "Нужна помощь с продуктами",
+			want:     "Нужна помощь с продуктами",
+		},
+		{
+			name:     "match",
+			headline: "Нужна помощь с " + searchMatchStart + "продуктами" + searchMatchStop,
+			want:     "Нужна помощь с <mark>продуктами</mark>",
+		},
+		{
+			name:     "markup in request text is escaped",
+			headline: `<script>alert(1)</script> ` + searchMatchStart + "лекарства" + searchMatchStop + ` & "вода"`,
+			want:     "&lt;script&gt;alert(1)&lt;/script&gt; <mark>лекарства</mark> &amp; &#34;вода&#34;",
+		},
+		{
+			name:     "mark tag in request text is escaped",
+			headline: "<mark>не совпадение</mark>",
+			want:     "&lt;mark&gt;не совпадение&lt;/mark&gt;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := highlightSearchMatches(tt.headline); got != tt.want {
+				t.Errorf("highlightSearchMatches() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
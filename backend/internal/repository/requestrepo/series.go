@@ -0,0 +1,275 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// requestSeriesColumns - поля серии повторяющихся заявок
+const requestSeriesColumns = `id, requester_id, title, COALESCE(description, '') as description, category_id, priority,
+	COALESCE(location, '') as location, location_lat, location_lon, volunteer_slots,
+	frequency, day_of_week, day_of_month, time_of_day, timezone, starts_on, ends_on,
+	default_volunteer_id, status, next_occurrence_at, created_at, updated_at`
+
+// CreateRequestSeries создает серию повторяющихся заявок
+func (r *RequestRepository) CreateRequestSeries(ctx context.Context, series *models.RequestSeries) (*models.RequestSeries, error) {
+	query := `
+		INSERT INTO request_series (
+			requester_id, title, description, category_id, priority, location, location_lat, location_lon,
+			volunteer_slots, frequency, day_of_week, day_of_month, time_of_day, timezone, starts_on, ends_on,
+			default_volunteer_id, status, next_occurrence_at
+		) VALUES (
+			:requester_id, :title, :description, :category_id, :priority, :location, :location_lat, :location_lon,
+			:volunteer_slots, :frequency, :day_of_week, :day_of_month, :time_of_day, :timezone, :starts_on, :ends_on,
+			:default_volunteer_id, :status, :next_occurrence_at
+		) RETURNING ` + requestSeriesColumns
+
+	rows, err := r.db.NamedQueryContext(ctx, query, series)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request series: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, errors.New("no rows returned after request series creation")
+	}
+
+	var created models.RequestSeries
+	if err := rows.StructScan(&created); err != nil {
+		return nil, fmt.Errorf("failed to scan created request series: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetRequestSeriesByID получает серию повторяющихся заявок по ID
+func (r *RequestRepository) GetRequestSeriesByID(ctx context.Context, id int) (*models.RequestSeries, error) {
+	var series models.RequestSeries
+	err := r.db.GetContext(ctx, &series, `SELECT `+requestSeriesColumns+` FROM request_series WHERE id = $1`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get request series: %w", err)
+	}
+
+	return &series, nil
+}
+
+// GetUserRequestSeries получает серии, созданные пользователем, и серии, где он волонтер по умолчанию
+func (r *RequestRepository) GetUserRequestSeries(ctx context.Context, userID int) ([]models.RequestSeries, error) {
+	query := `
+		SELECT ` + requestSeriesColumns + `
+		FROM request_series
+		WHERE requester_id = $1 OR default_volunteer_id = $1
+		ORDER BY status, created_at DESC
+	`
+
+	var series []models.RequestSeries
+	if err := r.db.SelectContext(ctx, &series, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user request series: %w", err)
+	}
+
+	return series, nil
+}
+
+// UpdateRequestSeries сохраняет изменения серии
+func (r *RequestRepository) UpdateRequestSeries(ctx context.Context, series *models.RequestSeries) (*models.RequestSeries, error) {
+	query := `
+		UPDATE request_series
+		SET title = :title,
+			description = :description,
+			category_id = :category_id,
+			priority = :priority,
+			location = :location,
+			location_lat = :location_lat,
+			location_lon = :location_lon,
+			volunteer_slots = :volunteer_slots,
+			frequency = :frequency,
+			day_of_week = :day_of_week,
+			day_of_month = :day_of_month,
+			time_of_day = :time_of_day,
+			timezone = :timezone,
+			starts_on = :starts_on,
+			ends_on = :ends_on,
+			default_volunteer_id = :default_volunteer_id,
+			status = :status,
+			next_occurrence_at = :next_occurrence_at
+		WHERE id = :id
+		RETURNING ` + requestSeriesColumns
+
+	rows, err := r.db.NamedQueryContext(ctx, query, series)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update request series: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, repository.ErrNotFound
+	}
+
+	var updated models.RequestSeries
+	if err := rows.StructScan(&updated); err != nil {
+		return nil, fmt.Errorf("failed to scan updated request series: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// GetDueRequestSeries получает активные серии, следующее повторение которых наступает до before
+func (r *RequestRepository) GetDueRequestSeries(ctx context.Context, before time.Time) ([]models.RequestSeries, error) {
+	query := `
+		SELECT ` + requestSeriesColumns + `
+		FROM request_series
+		WHERE status = $1 AND next_occurrence_at IS NOT NULL AND next_occurrence_at <= $2
+		ORDER BY next_occurrence_at
+	`
+
+	var series []models.RequestSeries
+	if err := r.db.SelectContext(ctx, &series, query, models.RequestSeriesStatusActive, before); err != nil {
+		return nil, fmt.Errorf("failed to get due request series: %w", err)
+	}
+
+	return series, nil
+}
+
+// GetLastSeriesOccurrenceTime возвращает время последнего созданного повторения серии
+func (r *RequestRepository) GetLastSeriesOccurrenceTime(ctx context.Context, seriesID int) (*time.Time, error) {
+	var last *time.Time
+	err := r.db.GetContext(ctx, &last, `SELECT MAX(scheduled_for) FROM help_requests WHERE series_id = $1`, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last series occurrence: %w", err)
+	}
+
+	return last, nil
+}
+
+// CreateSeriesOccurrence создает повторение серии, запланированное на series.NextOccurrenceAt,
+// и переносит следующее повторение на next. Если next равен nil, серия завершается.
+// Повторение создается только если серия все еще активна и ее следующее повторение
+// не изменилось с момента чтения, иначе возвращается repository.ErrConflict.
+// Волонтер серии по умолчанию сразу закрепляется за повторением. Если автор внес его
+// в черный список или волонтер заблокирован администратором, он снимается с серии.
+func (r *RequestRepository) CreateSeriesOccurrence(ctx context.Context, series *models.RequestSeries, next *time.Time) (*models.HelpRequest, error) {
+	if series.NextOccurrenceAt == nil {
+		return nil, repository.ErrConflict
+	}
+	scheduledFor := *series.NextOccurrenceAt
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	seriesStatus := models.RequestSeriesStatusActive
+	if next == nil {
+		seriesStatus = models.RequestSeriesStatusEnded
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE request_series
+		SET next_occurrence_at = $2, status = $3
+		WHERE id = $1 AND status = 'active' AND next_occurrence_at = $4
+	`, series.ID, next, seriesStatus, scheduledFor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance request series: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil, repository.ErrConflict
+	}
+
+	defaultVolunteerID := series.DefaultVolunteerID
+	if defaultVolunteerID != nil {
+		var eligible bool
+		err = tx.GetContext(ctx, &eligible, `
+			SELECT u.suspended_at IS NULL AND NOT EXISTS (
+				SELECT 1 FROM user_blocks b WHERE b.blocker_id = $1 AND b.blocked_id = u.id
+			)
+			FROM users u
+			WHERE u.id = $2 AND u.is_deleted = false
+		`, series.RequesterID, *defaultVolunteerID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to check default volunteer: %w", err)
+		}
+
+		if !eligible {
+			_, err = tx.ExecContext(ctx,
+				`UPDATE request_series SET default_volunteer_id = NULL WHERE id = $1`, series.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to remove default volunteer: %w", err)
+			}
+			defaultVolunteerID = nil
+		}
+	}
+
+	status := models.RequestStatusNew
+	if defaultVolunteerID != nil && series.VolunteerSlots == 1 {
+		status = models.RequestStatusInProgress
+	}
+
+	var occurrence models.HelpRequest
+	err = tx.GetContext(ctx, &occurrence, `
+		INSERT INTO help_requests (
+			title, description, status, category_id, priority, location, location_lat, location_lon,
+			requester_id, assigned_to, volunteer_slots, series_id, scheduled_for
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING `+requestReturningColumns,
+		series.Title, series.Description, models.RequestStatusNew, series.CategoryID, series.Priority,
+		series.Location, series.LocationLat, series.LocationLon, series.RequesterID,
+		defaultVolunteerID, series.VolunteerSlots, series.ID, scheduledFor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series occurrence: %w", err)
+	}
+
+	// Повторение создается планировщиком, поэтому автор перехода не указывается
+	err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+		RequestID: occurrence.ID,
+		To:        models.RequestStatusNew,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultVolunteerID != nil {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO request_assignments (request_id, volunteer_id) VALUES ($1, $2)`,
+			occurrence.ID, *defaultVolunteerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to assign default volunteer: %w", err)
+		}
+
+		if status == models.RequestStatusInProgress {
+			err = tx.GetContext(ctx, &occurrence, `
+				UPDATE help_requests SET status = $2 WHERE id = $1
+				RETURNING `+requestReturningColumns, occurrence.ID, status)
+			if err != nil {
+				return nil, fmt.Errorf("failed to start series occurrence: %w", err)
+			}
+
+			err = insertStatusHistory(ctx, tx, &models.RequestStatusChange{
+				RequestID:  occurrence.ID,
+				From:       models.RequestStatusNew,
+				To:         models.RequestStatusInProgress,
+				AssignedTo: defaultVolunteerID,
+				ActorID:    defaultVolunteerID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit series occurrence: %w", err)
+	}
+	series.DefaultVolunteerID = defaultVolunteerID
+
+	return &occurrence, nil
+}
@@ -0,0 +1,224 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// statsRollupLockKey - ключ advisory-блокировки пересчета сводок: пересчеты с нескольких
+// экземпляров сервера выполняются по очереди и не сталкиваются на первичном ключе дня
+const statsRollupLockKey = 7017
+
+// RefreshStatsRollups пересчитывает дневные сводки статистики: последние lookbackDays дней
+// до сегодняшнего включительно, а при первом запуске - всю историю. Сводки пересчитываются
+// целиком, поэтому повторный запуск безопасен и подхватывает поздние изменения заявок.
+// Текущие значения (открытые заявки, партнеры) записываются только в сводку за сегодня.
+func (r *RequestRepository) RefreshStatsRollups(ctx context.Context, lookbackDays int) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, statsRollupLockKey); err != nil {
+		return fmt.Errorf("failed to lock stats rollups: %w", err)
+	}
+
+	var since string
+	err = tx.GetContext(ctx, &since, `
+		SELECT COALESCE(
+			(SELECT MAX(day) FROM stats_daily) - $1::int,
+			LEAST(
+				(SELECT MIN(created_at)::date FROM help_requests),
+				(SELECT MIN(created_at)::date FROM users)
+			),
+			CURRENT_DATE
+		)::text
+	`, lookbackDays)
+	if err != nil {
+		return fmt.Errorf("failed to get stats rollup start: %w", err)
+	}
+
+	rollupQuery := `
+		INSERT INTO stats_daily (
+			day, created_requests, completed_requests, expired_requests, new_users, new_volunteers,
+			volunteer_minutes, ratings_count, ratings_sum, open_requests, partners, refreshed_at
+		)
+		SELECT
+			d.day,
+			COALESCE(created.n, 0), COALESCE(completed.n, 0), COALESCE(expired.n, 0),
+			COALESCE(registered.users, 0), COALESCE(registered.volunteers, 0),
+			COALESCE(worked.minutes, 0),
+			COALESCE(rated.n, 0), COALESCE(rated.total, 0),
+			CASE WHEN d.day = CURRENT_DATE THEN
+				(SELECT COUNT(*) FROM help_requests WHERE status = 'new' AND is_deleted = false)
+			END,
+			CASE WHEN d.day = CURRENT_DATE THEN (SELECT COUNT(*) FROM partner_organizations) END,
+			NOW()
+		FROM (
+			SELECT generate_series($1::date, CURRENT_DATE, interval '1 day')::date as day
+		) d
+		LEFT JOIN (
+			SELECT created_at::date as day, COUNT(*) as n
+			FROM help_requests
+			WHERE is_deleted = false AND created_at >= $1::date
+				AND status NOT IN ('pending_review', 'changes_requested', 'rejected')
+			GROUP BY 1
+		) created ON created.day = d.day
+		LEFT JOIN (
+			SELECT completed_at::date as day, COUNT(*) as n
+			FROM help_requests
+			WHERE is_deleted = false AND status = 'completed' AND completed_at >= $1::date
+			GROUP BY 1
+		) completed ON completed.day = d.day
+		LEFT JOIN (
+			SELECT COALESCE(due_at, updated_at)::date as day, COUNT(*) as n
+			FROM help_requests
+			WHERE is_deleted = false AND status = 'expired' AND COALESCE(due_at, updated_at) >= $1::date
+			GROUP BY 1
+		) expired ON expired.day = d.day
+		LEFT JOIN (
+			SELECT created_at::date as day,
+				COUNT(*) FILTER (WHERE role = 'user') as users,
+				COUNT(*) FILTER (WHERE role = 'volunteer') as volunteers
+			FROM users
+			WHERE created_at >= $1::date
+			GROUP BY 1
+		) registered ON registered.day = d.day
+		LEFT JOIN (
+			SELECT h.completed_at::date as day, SUM(e.minutes) as minutes
+			FROM request_time_entries e
+			INNER JOIN help_requests h ON e.request_id = h.id
+			WHERE h.is_deleted = false AND h.status = 'completed' AND h.completed_at >= $1::date
+			GROUP BY 1
+		) worked ON worked.day = d.day
+		LEFT JOIN (
+			SELECT created_at::date as day, COUNT(*) as n, SUM(rating) as total
+			FROM request_ratings
+			WHERE created_at >= $1::date
+			GROUP BY 1
+		) rated ON rated.day = d.day
+		ON CONFLICT (day) DO UPDATE
+		SET created_requests = EXCLUDED.created_requests,
+			completed_requests = EXCLUDED.completed_requests,
+			expired_requests = EXCLUDED.expired_requests,
+			new_users = EXCLUDED.new_users,
+			new_volunteers = EXCLUDED.new_volunteers,
+			volunteer_minutes = EXCLUDED.volunteer_minutes,
+			ratings_count = EXCLUDED.ratings_count,
+			ratings_sum = EXCLUDED.ratings_sum,
+			open_requests = COALESCE(EXCLUDED.open_requests, stats_daily.open_requests),
+			partners = COALESCE(EXCLUDED.partners, stats_daily.partners),
+			refreshed_at = NOW()
+	`
+	if _, err := tx.ExecContext(ctx, rollupQuery, since); err != nil {
+		return fmt.Errorf("failed to refresh stats rollups: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM stats_daily_volunteers WHERE day >= $1::date`, since); err != nil {
+		return fmt.Errorf("failed to clear active volunteers rollups: %w", err)
+	}
+
+	volunteersQuery := `
+		INSERT INTO stats_daily_volunteers (day, volunteer_id)
+		SELECT created_at::date, volunteer_id
+		FROM request_assignments
+		WHERE created_at >= $1::date
+		UNION
+		SELECT checked_in_at::date, volunteer_id
+		FROM request_time_entries
+		WHERE checked_in_at >= $1::date
+		UNION
+		SELECT h.completed_at::date, a.volunteer_id
+		FROM request_assignments a
+		INNER JOIN help_requests h ON a.request_id = h.id
+		WHERE h.status = 'completed' AND h.completed_at >= $1::date
+	`
+	if _, err := tx.ExecContext(ctx, volunteersQuery, since); err != nil {
+		return fmt.Errorf("failed to refresh active volunteers rollups: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit stats rollups: %w", err)
+	}
+
+	return nil
+}
+
+// GetRequestStats возвращает общую статистику по дневным сводкам. Число пользователей
+// и волонтеров считается по текущим ролям: сводки прошлых дней не пересчитываются
+// при смене роли или удалении пользователя.
+func (r *RequestRepository) GetRequestStats(ctx context.Context) (models.RequestStats, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(created_requests), 0) as total_requests,
+			COALESCE(SUM(completed_requests), 0) as total_completed_requests,
+			COALESCE(SUM(expired_requests), 0) as expired_requests,
+			(SELECT COUNT(*) FROM users WHERE role = 'user' AND is_deleted = false) as total_users,
+			(SELECT COUNT(*) FROM users WHERE role = 'volunteer' AND is_deleted = false) as total_volunteers,
+			ROUND(COALESCE(SUM(volunteer_minutes), 0) / 60.0)::int as total_volunteer_hours,
+			COALESCE(SUM(ratings_sum)::float8 / NULLIF(SUM(ratings_count), 0), 0) as average_rating,
+			COALESCE((
+				SELECT open_requests FROM stats_daily
+				WHERE open_requests IS NOT NULL ORDER BY day DESC LIMIT 1
+			), 0) as pending_requests,
+			COALESCE((
+				SELECT partners FROM stats_daily
+				WHERE partners IS NOT NULL ORDER BY day DESC LIMIT 1
+			), 0) as total_partners
+		FROM stats_daily
+	`
+
+	var stats models.RequestStats
+	if err := r.db.GetContext(ctx, &stats, query); err != nil {
+		return stats, fmt.Errorf("failed to get stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// statsMetricSQL возвращает таблицу сводок и агрегат показателя
+func statsMetricSQL(metric models.StatsMetric) (table, value string) {
+	switch metric {
+	case models.StatsMetricCompletedRequests:
+		return "stats_daily", "SUM(completed_requests)"
+	case models.StatsMetricActiveVolunteers:
+		return "stats_daily_volunteers", "COUNT(DISTINCT volunteer_id)"
+	case models.StatsMetricNewUsers:
+		return "stats_daily", "SUM(new_users + new_volunteers)"
+	default:
+		return "stats_daily", "SUM(created_requests)"
+	}
+}
+
+// GetStatsTimeseries возвращает временной ряд показателя по дневным сводкам.
+// Шаги без данных возвращаются с нулевым значением.
+func (r *RequestRepository) GetStatsTimeseries(ctx context.Context, filter models.StatsTimeseriesFilter) ([]models.StatsPoint, error) {
+	table, value := statsMetricSQL(filter.Metric)
+	query := fmt.Sprintf(`
+		SELECT to_char(p.period, 'YYYY-MM-DD') as period, COALESCE(v.value, 0) as value
+		FROM generate_series(
+			date_trunc($3, $1::date::timestamp),
+			($2::date - 1)::timestamp,
+			('1 ' || $3)::interval
+		) p(period)
+		LEFT JOIN (
+			SELECT date_trunc($3, day::timestamp) as period, %s as value
+			FROM %s
+			WHERE day >= $1::date AND day < $2::date
+			GROUP BY 1
+		) v ON v.period = p.period
+		ORDER BY p.period
+	`, value, table)
+
+	points := []models.StatsPoint{}
+	err := r.db.SelectContext(ctx, &points, query,
+		filter.From.Format("2006-01-02"), filter.To.Format("2006-01-02"), string(filter.Interval))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats timeseries: %w", err)
+	}
+
+	return points, nil
+}
@@ -0,0 +1,101 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ChangeRequestStatus переводит заявку в новый статус и записывает переход в историю.
+// Обновление выполняется только если заявка все еще находится в статусе change.From,
+// иначе возвращается repository.ErrConflict.
+func (r *RequestRepository) ChangeRequestStatus(ctx context.Context, change *models.RequestStatusChange) (*models.HelpRequest, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE help_requests
+		SET status = $2,
+			assigned_to = $3,
+			updated_at = NOW(),
+			completed_at = CASE WHEN $2 = 'completed' THEN NOW() ELSE completed_at END
+		WHERE id = $1 AND status = $4 AND is_deleted = false
+		RETURNING id, title, description, status, category_id, priority, location,
+			requester_id, assigned_to, is_deleted, created_at, updated_at, completed_at
+	`
+
+	var updated models.HelpRequest
+	err = tx.GetContext(ctx, &updated, query, change.RequestID, change.To, change.AssignedTo, change.From)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to change request status: %w", err)
+		}
+
+		// Заявки нет или ее статус уже изменился
+		if _, err := r.GetRequestByID(ctx, change.RequestID); err != nil {
+			return nil, err
+		}
+		return nil, repository.ErrConflict
+	}
+
+	if err := insertStatusHistory(ctx, tx, change); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit status change: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// GetRequestStatusHistory получает историю переходов заявки между статусами
+func (r *RequestRepository) GetRequestStatusHistory(ctx context.Context, requestID int) ([]models.RequestStatusHistory, error) {
+	query := `
+		SELECT
+			h.id, h.request_id, h.from_status, h.to_status, h.actor_id, h.reason, h.created_at,
+			u.username as actor_username, u.first_name as actor_first_name, u.last_name as actor_last_name
+		FROM request_status_history h
+		LEFT JOIN users u ON h.actor_id = u.id
+		WHERE h.request_id = $1
+		ORDER BY h.created_at, h.id
+	`
+
+	var history []models.RequestStatusHistory
+	err := r.db.SelectContext(ctx, &history, query, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request status history: %w", err)
+	}
+
+	return history, nil
+}
+
+// insertStatusHistory записывает переход заявки в историю в рамках транзакции
+func insertStatusHistory(ctx context.Context, tx *sqlx.Tx, change *models.RequestStatusChange) error {
+	query := `
+		INSERT INTO request_status_history (request_id, from_status, to_status, actor_id, reason)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`
+
+	// Для только что созданной заявки предыдущего статуса нет
+	var fromStatus interface{}
+	if change.From != "" {
+		fromStatus = change.From
+	}
+
+	_, err := tx.ExecContext(ctx, query, change.RequestID, fromStatus, change.To, change.ActorID, change.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to insert request status history: %w", err)
+	}
+
+	return nil
+}
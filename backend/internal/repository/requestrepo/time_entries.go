@@ -0,0 +1,167 @@
+package requestrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+// timeEntryColumns - поля отметки времени волонтера
+const timeEntryColumns = `id, request_id, volunteer_id, checked_in_at, checked_out_at, minutes,
+	corrected_by, correction_reason, created_at, updated_at`
+
+// cappedMinutesSQL возвращает SQL-выражение засчитанных минут между началом и окончанием работы
+// с ограничением сверху параметром capParam
+func cappedMinutesSQL(from, to, capParam string) string {
+	return fmt.Sprintf(`LEAST(FLOOR(EXTRACT(EPOCH FROM (%s - %s)) / 60), %s)::int`, to, from, capParam)
+}
+
+// CheckIn открывает отметку времени волонтера по заявке.
+// Если у волонтера уже есть незакрытая отметка по этой заявке, возвращается repository.ErrConflict.
+func (r *RequestRepository) CheckIn(ctx context.Context, requestID, volunteerID int, at time.Time) (*models.TimeEntry, error) {
+	query := `
+		INSERT INTO request_time_entries (request_id, volunteer_id, checked_in_at)
+		VALUES ($1, $2, $3)
+		RETURNING ` + timeEntryColumns
+
+	var entry models.TimeEntry
+	if err := r.db.GetContext(ctx, &entry, query, requestID, volunteerID, at); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, repository.ErrConflict
+		}
+		return nil, fmt.Errorf("failed to check in: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// CheckOut закрывает незакрытую отметку волонтера по заявке.
+// Засчитывается не больше capMinutes минут. Если незакрытой отметки нет, возвращается repository.ErrNotFound.
+func (r *RequestRepository) CheckOut(ctx context.Context, requestID, volunteerID int, at time.Time, capMinutes int) (*models.TimeEntry, error) {
+	query := `
+		UPDATE request_time_entries
+		SET checked_out_at = $3,
+			minutes = ` + cappedMinutesSQL("checked_in_at", "$3", "$4") + `
+		WHERE request_id = $1 AND volunteer_id = $2 AND checked_out_at IS NULL
+		RETURNING ` + timeEntryColumns
+
+	var entry models.TimeEntry
+	if err := r.db.GetContext(ctx, &entry, query, requestID, volunteerID, at, capMinutes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to check out: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// CloseOpenTimeEntries закрывает все незакрытые отметки по заявке моментом at,
+// засчитывая не больше capMinutes минут на отметку
+func (r *RequestRepository) CloseOpenTimeEntries(ctx context.Context, requestID int, at time.Time, capMinutes int) error {
+	query := `
+		UPDATE request_time_entries
+		SET checked_out_at = GREATEST($2, checked_in_at),
+			minutes = ` + cappedMinutesSQL("checked_in_at", "GREATEST($2, checked_in_at)", "$3") + `
+		WHERE request_id = $1 AND checked_out_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, requestID, at, capMinutes); err != nil {
+		return fmt.Errorf("failed to close open time entries: %w", err)
+	}
+
+	return nil
+}
+
+// GetRequestTimeEntries получает отметки времени по заявке в хронологическом порядке
+func (r *RequestRepository) GetRequestTimeEntries(ctx context.Context, requestID int) ([]models.TimeEntry, error) {
+	query := `
+		SELECT ` + timeEntryColumns + `
+		FROM request_time_entries
+		WHERE request_id = $1
+		ORDER BY checked_in_at, id
+	`
+
+	var entries []models.TimeEntry
+	if err := r.db.SelectContext(ctx, &entries, query, requestID); err != nil {
+		return nil, fmt.Errorf("failed to get request time entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetTimeEntryByID получает отметку времени по ID
+func (r *RequestRepository) GetTimeEntryByID(ctx context.Context, id int) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := r.db.GetContext(ctx, &entry, `SELECT `+timeEntryColumns+` FROM request_time_entries WHERE id = $1`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get time entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// CorrectTimeEntry заменяет время начала и окончания отметки и пересчитывает засчитанные минуты
+// с ограничением capMinutes. Автор и причина исправления сохраняются в отметке.
+func (r *RequestRepository) CorrectTimeEntry(ctx context.Context, entry *models.TimeEntry, capMinutes int) (*models.TimeEntry, error) {
+	query := `
+		UPDATE request_time_entries
+		SET checked_in_at = $2,
+			checked_out_at = $3,
+			minutes = ` + cappedMinutesSQL("$2::timestamptz", "$3::timestamptz", "$4") + `,
+			corrected_by = $5,
+			correction_reason = $6
+		WHERE id = $1
+		RETURNING ` + timeEntryColumns
+
+	var corrected models.TimeEntry
+	err := r.db.GetContext(ctx, &corrected, query,
+		entry.ID, entry.CheckedInAt, entry.CheckedOutAt, capMinutes, entry.CorrectedBy, entry.CorrectionReason)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			// Исправление закрыло бы одну из отметок, пока открыта другая
+			return nil, repository.ErrConflict
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to correct time entry: %w", err)
+	}
+
+	return &corrected, nil
+}
+
+// RecalculateVolunteerHours пересчитывает user_stats.volunteer_hours волонтера
+// по отметкам времени в завершенных заявках. Пересчет целиком, а не прибавление,
+// позволяет безопасно повторять его после исправлений отметок.
+// Минуты переводятся в часы с округлением до ближайшего часа.
+func (r *RequestRepository) RecalculateVolunteerHours(ctx context.Context, volunteerID int) error {
+	query := `
+		INSERT INTO user_stats (user_id, volunteer_hours)
+		SELECT $1, ROUND(COALESCE(SUM(e.minutes), 0) / 60.0)::int
+		FROM request_time_entries e
+		INNER JOIN help_requests h ON e.request_id = h.id
+		WHERE e.volunteer_id = $1 AND h.status = 'completed' AND h.is_deleted = false
+		ON CONFLICT (user_id) DO UPDATE
+		SET volunteer_hours = EXCLUDED.volunteer_hours,
+			updated_at = NOW()
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, volunteerID); err != nil {
+		return fmt.Errorf("failed to recalculate volunteer hours: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package requestrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// userBlockRow - запись черного списка вместе с обоими пользователями
+type userBlockRow struct {
+	models.UserBlock
+	BlockerUsername  string `db:"blocker_username"`
+	BlockerFirstName string `db:"blocker_first_name"`
+	BlockerLastName  string `db:"blocker_last_name"`
+	BlockerPhotoURL  string `db:"blocker_photo_url"`
+	BlockedUsername  string `db:"blocked_username"`
+	BlockedFirstName string `db:"blocked_first_name"`
+	BlockedLastName  string `db:"blocked_last_name"`
+	BlockedPhotoURL  string `db:"blocked_photo_url"`
+}
+
+// BlockUser добавляет пользователя blockedID в черный список blockerID.
+// Повторная блокировка обновляет только причину. Заблокированный снимается
+// с серий автора, в которых был волонтером по умолчанию.
+func (r *RequestRepository) BlockUser(ctx context.Context, blockerID, blockedID int, reason string) (*models.UserBlock, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var block models.UserBlock
+	err = tx.GetContext(ctx, &block, `
+		INSERT INTO user_blocks (blocker_id, blocked_id, reason)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET reason = EXCLUDED.reason
+		RETURNING blocker_id, blocked_id, reason, created_at
+	`, blockerID, blockedID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to block user: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE request_series SET default_volunteer_id = NULL
+		WHERE requester_id = $1 AND default_volunteer_id = $2
+	`, blockerID, blockedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove blocked default volunteer: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit user block: %w", err)
+	}
+
+	return &block, nil
+}
+
+// UnblockUser убирает пользователя blockedID из черного списка blockerID.
+// Если пользователя в черном списке нет, возвращается repository.ErrNotFound.
+func (r *RequestRepository) UnblockUser(ctx context.Context, blockerID, blockedID int) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2
+	`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// GetUserBlocks возвращает черный список пользователя, новые записи первыми
+func (r *RequestRepository) GetUserBlocks(ctx context.Context, blockerID int) ([]models.UserBlock, error) {
+	return r.selectUserBlocks(ctx, "b.blocker_id", blockerID)
+}
+
+// GetUserBlockedBy возвращает записи черных списков, в которые внесен пользователь, новые первыми
+func (r *RequestRepository) GetUserBlockedBy(ctx context.Context, blockedID int) ([]models.UserBlock, error) {
+	return r.selectUserBlocks(ctx, "b.blocked_id", blockedID)
+}
+
+// selectUserBlocks выбирает записи черных списков, у которых column равна userID
+func (r *RequestRepository) selectUserBlocks(ctx context.Context, column string, userID int) ([]models.UserBlock, error) {
+	var rows []userBlockRow
+	err := r.db.SelectContext(ctx, &rows, `
+		SELECT
+			b.blocker_id, b.blocked_id, b.reason, b.created_at,
+			COALESCE(blocker.username, '') as blocker_username,
+			COALESCE(blocker.first_name, '') as blocker_first_name,
+			COALESCE(blocker.last_name, '') as blocker_last_name,
+			COALESCE(blocker.photo_url, '') as blocker_photo_url,
+			COALESCE(blocked.username, '') as blocked_username,
+			COALESCE(blocked.first_name, '') as blocked_first_name,
+			COALESCE(blocked.last_name, '') as blocked_last_name,
+			COALESCE(blocked.photo_url, '') as blocked_photo_url
+		FROM user_blocks b
+		INNER JOIN users blocker ON b.blocker_id = blocker.id
+		INNER JOIN users blocked ON b.blocked_id = blocked.id
+		WHERE `+column+` = $1
+		ORDER BY b.created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user blocks: %w", err)
+	}
+
+	blocks := make([]models.UserBlock, 0, len(rows))
+	for _, row := range rows {
+		block := row.UserBlock
+		block.Blocker = &models.UserShort{
+			ID:        block.BlockerID,
+			Username:  row.BlockerUsername,
+			FirstName: row.BlockerFirstName,
+			LastName:  row.BlockerLastName,
+			PhotoURL:  row.BlockerPhotoURL,
+		}
+		block.Blocked = &models.UserShort{
+			ID:        block.BlockedID,
+			Username:  row.BlockedUsername,
+			FirstName: row.BlockedFirstName,
+			LastName:  row.BlockedLastName,
+			PhotoURL:  row.BlockedPhotoURL,
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// IsBlockedByAny проверяет, внесен ли пользователь blockedID в черный список кого-либо из blockerIDs
+func (r *RequestRepository) IsBlockedByAny(ctx context.Context, blockerIDs []int, blockedID int) (bool, error) {
+	if len(blockerIDs) == 0 {
+		return false, nil
+	}
+
+	var blocked bool
+	err := r.db.GetContext(ctx, &blocked, `
+		SELECT EXISTS (
+			SELECT 1 FROM user_blocks WHERE blocker_id = ANY($1) AND blocked_id = $2
+		)
+	`, pq.Array(blockerIDs), blockedID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check user blocks: %w", err)
+	}
+
+	return blocked, nil
+}
@@ -175,21 +175,18 @@ func (r *UserRepository) GetUserFullInfo(ctx context.Context, userID int) (*mode
 			COALESCE(g.level, 1) as level,
 			COALESCE(g.experience, 0) as experience,
 			COALESCE(completed.count, 0) as completed_requests,
-			COALESCE(vol_hours.hours, 0) as volunteer_hours
+			COALESCE(us.volunteer_hours, 0) as volunteer_hours,
+			COALESCE(us.rating, 0) as rating,
+			COALESCE(us.ratings_count, 0) as ratings_count
 		FROM users u
 		LEFT JOIN user_game_data g ON u.id = g.user_id
+		LEFT JOIN user_stats us ON u.id = us.user_id
 		LEFT JOIN (
 			SELECT requester_id, COUNT(*) as count
 			FROM help_requests
 			WHERE status = 'completed'
 			GROUP BY requester_id
 		) completed ON u.id = completed.requester_id
-		LEFT JOIN (
-			SELECT assigned_user_id, SUM(EXTRACT(EPOCH FROM (completed_at - updated_at))/3600) as hours
-			FROM help_requests
-			WHERE status = 'completed' AND assigned_user_id IS NOT NULL
-			GROUP BY assigned_user_id
-		) vol_hours ON u.id = vol_hours.assigned_user_id
 		WHERE u.id = $1
 	`
 
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 
 	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/events"
 	"moshosp/backend/internal/repository"
 )
 
@@ -18,6 +19,8 @@ type GameService struct {
 	userRepo       *repository.UserRepository
 	requestRepo    *repository.RequestRepository
 	achievementSvc *AchievementService
+	// events - поток событий для клиентов; nil, если поток не подключен
+	events *events.Hub
 }
 
 // NewGameService создает новый экземпляр GameService
@@ -35,6 +38,11 @@ func NewGameService(
 	}
 }
 
+// SetEventHub задает Hub, в который публикуются уведомления о достижениях
+func (s *GameService) SetEventHub(hub *events.Hub) {
+	s.events = hub
+}
+
 // GetUserGameData получает игровые данные пользователя
 func (s *GameService) GetUserGameData(ctx context.Context, userID int) (*models.UserGameData, error) {
 	// Получаем базовый профиль
@@ -214,10 +222,16 @@ func (s *GameService) UpdateAchievementProgress(ctx context.Context, userID int,
 			CreatedAt:     time.Now(),
 		}
 
-		_, err = s.gameRepo.CreateNotification(ctx, notification)
+		created, err := s.gameRepo.CreateNotification(ctx, notification)
 		if err != nil {
 			// Логируем ошибку, но не прерываем выполнение
 			fmt.Printf("Failed to create achievement notification: %v\n", err)
+		} else if s.events != nil {
+			s.events.Publish(events.Event{
+				Type:   events.TypeNotification,
+				Data:   created,
+				UserID: userID,
+			})
 		}
 	}
 
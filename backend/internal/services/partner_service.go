@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+	"moshosp/backend/internal/repository/partnerrepo"
+)
+
+// PartnerService предоставляет методы для работы с партнерскими организациями и их сотрудниками
+type PartnerService struct {
+	repo   *repository.Repository
+	logger *logrus.Logger
+}
+
+// NewPartnerService создает новый экземпляр сервиса партнерских организаций
+func NewPartnerService(repo *repository.Repository, logger *logrus.Logger) *PartnerService {
+	return &PartnerService{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// GetPartners возвращает партнерские организации; verifiedOnly оставляет только проверенные
+func (s *PartnerService) GetPartners(verifiedOnly bool) ([]models.PartnerOrganization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.repo.Partner.GetPartners(ctx, verifiedOnly)
+}
+
+// GetPartner возвращает партнерскую организацию
+func (s *PartnerService) GetPartner(partnerID int) (*models.PartnerOrganization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	partner, err := s.repo.Partner.GetPartnerByID(ctx, partnerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return partner, nil
+}
+
+// CreatePartner создает партнерскую организацию. Доступно только администраторам.
+// Новая организация не проверена: заявки от ее имени появятся после проверки.
+func (s *PartnerService) CreatePartner(adminID int, input models.PartnerInput) (*models.PartnerOrganization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+	if err := validatePartnerInput(&input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Partner.CreatePartner(ctx, &input)
+}
+
+// UpdatePartner заменяет профиль организации. Доступно администраторам и руководителям организации.
+func (s *PartnerService) UpdatePartner(userID, partnerID int, input models.PartnerInput) (*models.PartnerOrganization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requirePartnerManager(ctx, userID, partnerID); err != nil {
+		return nil, err
+	}
+	if err := validatePartnerInput(&input); err != nil {
+		return nil, err
+	}
+
+	partner, err := s.repo.Partner.UpdatePartner(ctx, partnerID, &input)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return partner, nil
+}
+
+// SetPartnerVerified отмечает организацию проверенной или снимает отметку. Доступно только администраторам.
+func (s *PartnerService) SetPartnerVerified(adminID, partnerID int, input models.PartnerVerificationInput) (*models.PartnerOrganization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Partner.SetPartnerVerified(ctx, partnerID, input.IsVerified); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s.repo.Partner.GetPartnerByID(ctx, partnerID)
+}
+
+// DeletePartner удаляет организацию. Доступно только администраторам.
+// Заявки организации остаются у их авторов.
+func (s *PartnerService) DeletePartner(adminID, partnerID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Partner.DeletePartner(ctx, partnerID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetPartnerMembers возвращает сотрудников организации. Доступно администраторам и сотрудникам организации.
+func (s *PartnerService) GetPartnerMembers(userID, partnerID int) ([]models.PartnerMember, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.repo.Partner.GetMemberRole(ctx, partnerID, userID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		if err := s.requireAdmin(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.GetPartner(partnerID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Partner.GetPartnerMembers(ctx, partnerID)
+}
+
+// SavePartnerMember добавляет сотрудника в организацию или меняет его роль.
+// Доступно администраторам и руководителям организации; по умолчанию сотрудник получает роль staff.
+func (s *PartnerService) SavePartnerMember(userID, partnerID int, input models.PartnerMemberInput) ([]models.PartnerMember, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if input.UserID <= 0 {
+		return nil, fmt.Errorf("%w: userId is required", models.ErrInvalidRequest)
+	}
+	if input.Role == "" {
+		input.Role = models.PartnerMemberRoleStaff
+	}
+	if input.Role != models.PartnerMemberRoleManager && input.Role != models.PartnerMemberRoleStaff {
+		return nil, fmt.Errorf("%w: unknown role %q", models.ErrInvalidRequest, input.Role)
+	}
+
+	if err := s.requirePartnerManager(ctx, userID, partnerID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Partner.SavePartnerMember(ctx, partnerID, input.UserID, input.Role); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, models.ErrNotFound
+		case errors.Is(err, partnerrepo.ErrUserNotFound):
+			return nil, fmt.Errorf("%w: user not found", models.ErrInvalidRequest)
+		}
+		return nil, err
+	}
+
+	return s.repo.Partner.GetPartnerMembers(ctx, partnerID)
+}
+
+// DeletePartnerMember исключает сотрудника из организации.
+// Доступно администраторам и руководителям организации; сотрудник может выйти из организации сам.
+func (s *PartnerService) DeletePartnerMember(userID, partnerID, memberID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if userID != memberID {
+		if err := s.requirePartnerManager(ctx, userID, partnerID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Partner.DeletePartnerMember(ctx, partnerID, memberID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// validatePartnerInput проверяет и нормализует профиль организации
+func validatePartnerInput(input *models.PartnerInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return fmt.Errorf("%w: name is required", models.ErrInvalidRequest)
+	}
+	if len([]rune(input.Name)) > 100 {
+		return fmt.Errorf("%w: name must be at most 100 characters", models.ErrInvalidRequest)
+	}
+	if len(input.ContactEmail) > 100 {
+		return fmt.Errorf("%w: contactEmail must be at most 100 characters", models.ErrInvalidRequest)
+	}
+	if len(input.ContactPhone) > 20 {
+		return fmt.Errorf("%w: contactPhone must be at most 20 characters", models.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// requirePartnerManager проверяет, что пользователь - администратор или руководитель организации
+func (s *PartnerService) requirePartnerManager(ctx context.Context, userID, partnerID int) error {
+	role, err := s.repo.Partner.GetMemberRole(ctx, partnerID, userID)
+	if err == nil && role == models.PartnerMemberRoleManager {
+		return nil
+	}
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	return s.requireAdmin(ctx, userID)
+}
+
+// requireAdmin проверяет, что пользователь - администратор
+func (s *PartnerService) requireAdmin(ctx context.Context, userID int) error {
+	user, err := s.repo.User.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.UserRoleAdmin {
+		return models.ErrForbidden
+	}
+
+	return nil
+}
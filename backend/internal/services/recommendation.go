@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+const (
+	// recommendationMaxDistanceKm - дальше этого расстояния от дома близость заявки не учитывается
+	recommendationMaxDistanceKm = 20.0
+	// recommendationMaxWaitHours - после этого времени ожидания сигнал ожидания максимален
+	recommendationMaxWaitHours = 48.0
+	// recommendationMaxRating - верхняя граница рейтинга волонтера
+	recommendationMaxRating = 5.0
+	// recommendationTopFactors - сколько главных причин объясняет каждую рекомендацию
+	recommendationTopFactors = 3
+	// earthRadiusKm - средний радиус Земли
+	earthRadiusKm = 6371.0
+)
+
+// scoreRecommendation оценивает заявку для волонтера по взвешенной сумме сигналов
+// и объясняет оценку главными причинами. Функция не обращается к базе данных:
+// все сведения о заявке и волонтере передаются в аргументах.
+func scoreRecommendation(candidate *models.RecommendationCandidate, profile *models.VolunteerProfile,
+	weights models.RecommendationWeights, now time.Time) models.RecommendedRequest {
+	var factors []models.RecommendationFactor
+	add := func(name string, weight, signal float64, description string) {
+		if weight <= 0 || signal <= 0 {
+			return
+		}
+		factors = append(factors, models.RecommendationFactor{
+			Name:        name,
+			Score:       weight * signal,
+			Description: description,
+		})
+	}
+
+	if completed := profile.CompletedByCategory[candidate.CategoryID]; completed > 0 {
+		add(models.RecommendationFactorCategory, weights.Category, categorySignal(profile.CompletedByCategory, candidate.CategoryID),
+			fmt.Sprintf("Вы выполнили %d заявок в категории «%s»", completed, candidate.CategoryName))
+	}
+
+	if profile.HomeLat != nil && profile.HomeLon != nil && candidate.LocationLat != nil && candidate.LocationLon != nil {
+		distance := haversineKm(*profile.HomeLat, *profile.HomeLon, *candidate.LocationLat, *candidate.LocationLon)
+		candidate.DistanceKm = &distance
+		add(models.RecommendationFactorDistance, weights.Distance, distanceSignal(distance),
+			fmt.Sprintf("%.1f км от вашего дома", distance))
+	}
+
+	priority := prioritySignal(candidate.Priority)
+	add(models.RecommendationFactorRating, weights.Rating, math.Min(profile.Rating/recommendationMaxRating, 1)*priority,
+		fmt.Sprintf("С вашим рейтингом %.1f вам доверяют срочные заявки", profile.Rating))
+
+	waited := now.Sub(candidate.CreatedAt)
+	add(models.RecommendationFactorWait, weights.Wait, waitSignal(waited),
+		fmt.Sprintf("Заявка ждет волонтера уже %s", formatWaitDuration(int(waited.Minutes()))))
+
+	add(models.RecommendationFactorPriority, weights.Priority, priority,
+		fmt.Sprintf("Приоритет заявки: %s", candidate.Priority))
+
+	// Оценка - сумма вкладов всех сигналов, объясняются только главные
+	score := 0.0
+	for _, factor := range factors {
+		score += factor.Score
+	}
+
+	sort.SliceStable(factors, func(i, j int) bool {
+		return factors[i].Score > factors[j].Score
+	})
+	if len(factors) > recommendationTopFactors {
+		factors = factors[:recommendationTopFactors]
+	}
+	for i := range factors {
+		factors[i].Score = roundScore(factors[i].Score)
+	}
+
+	return models.RecommendedRequest{
+		Request: candidate.RequestFullInfo,
+		Score:   roundScore(score),
+		Factors: factors,
+	}
+}
+
+// categorySignal - доля выполненных заявок категории относительно самой частой категории волонтера
+func categorySignal(completedByCategory map[int]int, categoryID int) float64 {
+	most := 0
+	for _, count := range completedByCategory {
+		if count > most {
+			most = count
+		}
+	}
+	if most == 0 {
+		return 0
+	}
+
+	return float64(completedByCategory[categoryID]) / float64(most)
+}
+
+// distanceSignal убывает линейно от 1 у дома волонтера до 0 на расстоянии recommendationMaxDistanceKm
+func distanceSignal(distanceKm float64) float64 {
+	return math.Max(0, 1-distanceKm/recommendationMaxDistanceKm)
+}
+
+// waitSignal растет линейно с временем ожидания до 1 через recommendationMaxWaitHours
+func waitSignal(waited time.Duration) float64 {
+	return math.Min(math.Max(0, waited.Hours()/recommendationMaxWaitHours), 1)
+}
+
+// prioritySignal переводит приоритет заявки в сигнал: высокий - 1, средний - 0.5, низкий - 0
+func prioritySignal(priority models.RequestPriority) float64 {
+	switch priority {
+	case models.RequestPriorityHigh:
+		return 1
+	case models.RequestPriorityMedium:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// haversineKm вычисляет расстояние в километрах между двумя точками по формуле гаверсинусов
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Pow(math.Sin(dLon/2), 2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// roundScore округляет оценку до сотых для ответа API
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// kmPerDegreeLat - километров в одном градусе широты при earthRadiusKm
+const kmPerDegreeLat = earthRadiusKm * math.Pi / 180
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestScoreRecommendation(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	homeLat, homeLon := 55.75, 37.62
+
+	tests := []struct {
+		name        string
+		candidate   models.RecommendationCandidate
+		profile     models.VolunteerProfile
+		weights     models.RecommendationWeights
+		wantScore   float64
+		wantFactors []string
+	}{
+		{
+			name: "all signals, only top three explained",
+			candidate: models.RecommendationCandidate{
+				RequestFullInfo: models.RequestFullInfo{
+					Priority:    models.RequestPriorityHigh,
+					LocationLat: floatPtr(homeLat),
+					LocationLon: floatPtr(homeLon),
+					CreatedAt:   now.Add(-48 * time.Hour),
+				},
+				CategoryID: 1,
+			},
+			profile: models.VolunteerProfile{
+				HomeLat:             floatPtr(homeLat),
+				HomeLon:             floatPtr(homeLon),
+				Rating:              5,
+				CompletedByCategory: map[int]int{1: 2},
+			},
+			weights: models.DefaultRecommendationWeights,
+			// category 3 + distance 3 + rating 1 + wait 2 + priority 2: в оценку входят все сигналы
+			wantScore: 11,
+			wantFactors: []string{
+				models.RecommendationFactorCategory,
+				models.RecommendationFactorDistance,
+				models.RecommendationFactorWait,
+			},
+		},
+		{
+			name: "distance halfway to cut-off",
+			candidate: models.RecommendationCandidate{
+				RequestFullInfo: models.RequestFullInfo{
+					Priority:    models.RequestPriorityLow,
+					LocationLat: floatPtr(homeLat + 10/kmPerDegreeLat),
+					LocationLon: floatPtr(homeLon),
+					CreatedAt:   now,
+				},
+			},
+			profile: models.VolunteerProfile{
+				HomeLat: floatPtr(homeLat),
+				HomeLon: floatPtr(homeLon),
+			},
+			weights:     models.DefaultRecommendationWeights,
+			wantScore:   1.5,
+			wantFactors: []string{models.RecommendationFactorDistance},
+		},
+		{
+			name: "distance beyond cut-off",
+			candidate: models.RecommendationCandidate{
+				RequestFullInfo: models.RequestFullInfo{
+					Priority:    models.RequestPriorityLow,
+					LocationLat: floatPtr(homeLat + 30/kmPerDegreeLat),
+					LocationLon: floatPtr(homeLon),
+					CreatedAt:   now,
+				},
+			},
+			profile: models.VolunteerProfile{
+				HomeLat: floatPtr(homeLat),
+				HomeLon: floatPtr(homeLon),
+			},
+			weights:     models.DefaultRecommendationWeights,
+			wantScore:   0,
+			wantFactors: nil,
+		},
+		{
+			name: "no home location",
+			candidate: models.RecommendationCandidate{
+				RequestFullInfo: models.RequestFullInfo{
+					Priority:    models.RequestPriorityLow,
+					LocationLat: floatPtr(homeLat),
+					LocationLon: floatPtr(homeLon),
+					CreatedAt:   now,
+				},
+			},
+			profile:     models.VolunteerProfile{},
+			weights:     models.DefaultRecommendationWeights,
+			wantScore:   0,
+			wantFactors: nil,
+		},
+		{
+			name: "rating scaled by priority",
+			candidate: models.RecommendationCandidate{
+				RequestFullInfo: models.RequestFullInfo{
+					Priority:  models.RequestPriorityMedium,
+					CreatedAt: now,
+				},
+			},
+			profile: models.VolunteerProfile{Rating: 4},
+			weights: models.DefaultRecommendationWeights,
+			// priority 2*0.5 + rating 1*(4/5)*0.5
+			wantScore: 1.4,
+			wantFactors: []string{
+				models.RecommendationFactorPriority,
+				models.RecommendationFactorRating,
+			},
+		},
+		{
+			name: "zero and negative weights skip signals",
+			candidate: models.RecommendationCandidate{
+				RequestFullInfo: models.RequestFullInfo{
+					Priority:  models.RequestPriorityHigh,
+					CreatedAt: now.Add(-24 * time.Hour),
+				},
+				CategoryID: 1,
+			},
+			profile: models.VolunteerProfile{
+				Rating:              5,
+				CompletedByCategory: map[int]int{1: 3},
+			},
+			weights: models.RecommendationWeights{
+				Category: 0,
+				Rating:   -1,
+				Wait:     2,
+				Priority: 0,
+			},
+			// wait 2*0.5
+			wantScore:   1,
+			wantFactors: []string{models.RecommendationFactorWait},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreRecommendation(&tt.candidate, &tt.profile, tt.weights, now)
+
+			if got.Score != tt.wantScore {
+				t.Errorf("score = %v, want %v", got.Score, tt.wantScore)
+			}
+
+			var names []string
+			for _, factor := range got.Factors {
+				names = append(names, factor.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantFactors) {
+				t.Errorf("factors = %v, want %v", names, tt.wantFactors)
+			}
+		})
+	}
+}
+
+func TestScoreRecommendationSetsDistance(t *testing.T) {
+	candidate := models.RecommendationCandidate{
+		RequestFullInfo: models.RequestFullInfo{
+			LocationLat: floatPtr(55.75 + 30/kmPerDegreeLat),
+			LocationLon: floatPtr(37.62),
+		},
+	}
+	profile := models.VolunteerProfile{HomeLat: floatPtr(55.75), HomeLon: floatPtr(37.62)}
+
+	got := scoreRecommendation(&candidate, &profile, models.DefaultRecommendationWeights, time.Now())
+
+	// Расстояние показывается волонтеру, даже если заявка дальше границы сигнала
+	if got.Request.DistanceKm == nil || math.Abs(*got.Request.DistanceKm-30) > 0.01 {
+		t.Errorf("distance = %v, want 30 km", got.Request.DistanceKm)
+	}
+}
+
+func TestCategorySignal(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed map[int]int
+		category  int
+		want      float64
+	}{
+		{name: "no completed requests", completed: nil, category: 1, want: 0},
+		{name: "most frequent category", completed: map[int]int{1: 4, 2: 2}, category: 1, want: 1},
+		{name: "share of most frequent", completed: map[int]int{1: 4, 2: 2}, category: 2, want: 0.5},
+		{name: "other category", completed: map[int]int{1: 4}, category: 3, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := categorySignal(tt.completed, tt.category); got != tt.want {
+				t.Errorf("categorySignal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistanceSignal(t *testing.T) {
+	tests := []struct {
+		distanceKm float64
+		want       float64
+	}{
+		{distanceKm: 0, want: 1},
+		{distanceKm: 5, want: 0.75},
+		{distanceKm: 10, want: 0.5},
+		{distanceKm: recommendationMaxDistanceKm, want: 0},
+		{distanceKm: 35, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := distanceSignal(tt.distanceKm); got != tt.want {
+			t.Errorf("distanceSignal(%v) = %v, want %v", tt.distanceKm, got, tt.want)
+		}
+	}
+}
+
+func TestWaitSignal(t *testing.T) {
+	tests := []struct {
+		waited time.Duration
+		want   float64
+	}{
+		{waited: -time.Hour, want: 0},
+		{waited: 0, want: 0},
+		{waited: 12 * time.Hour, want: 0.25},
+		{waited: 24 * time.Hour, want: 0.5},
+		{waited: 48 * time.Hour, want: 1},
+		{waited: 96 * time.Hour, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := waitSignal(tt.waited); got != tt.want {
+			t.Errorf("waitSignal(%v) = %v, want %v", tt.waited, got, tt.want)
+		}
+	}
+}
+
+func TestPrioritySignal(t *testing.T) {
+	tests := []struct {
+		priority models.RequestPriority
+		want     float64
+	}{
+		{priority: models.RequestPriorityHigh, want: 1},
+		{priority: models.RequestPriorityMedium, want: 0.5},
+		{priority: models.RequestPriorityLow, want: 0},
+		{priority: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := prioritySignal(tt.priority); got != tt.want {
+			t.Errorf("prioritySignal(%q) = %v, want %v", tt.priority, got, tt.want)
+		}
+	}
+}
+
+func TestHaversineKm(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want, tolerance        float64
+	}{
+		{name: "same point", lat1: 55.75, lon1: 37.62, lat2: 55.75, lon2: 37.62, want: 0, tolerance: 1e-9},
+		{name: "one degree of latitude", lat1: 55, lon1: 37, lat2: 56, lon2: 37, want: kmPerDegreeLat, tolerance: 1e-6},
+		{name: "Moscow to Saint Petersburg", lat1: 55.7558, lon1: 37.6173, lat2: 59.9343, lon2: 30.3351, want: 634, tolerance: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := haversineKm(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if math.Abs(got-tt.want) > tt.tolerance {
+				t.Errorf("haversineKm() = %v, want %v ± %v", got, tt.want, tt.tolerance)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+	"moshosp/backend/internal/repository/requestrepo"
+)
+
+// defaultReportHideThreshold - сколько открытых жалоб скрывает заявку или комментарий до решения администратора
+const defaultReportHideThreshold = 3
+
+// maxAbuseReportDetailsLength - максимальная длина описания жалобы
+const maxAbuseReportDetailsLength = 1000
+
+// SetReportHideThreshold задает число открытых жалоб, после которого контент скрывается автоматически.
+// 0 отключает автоматическое скрытие.
+func (s *RequestService) SetReportHideThreshold(threshold int) {
+	s.reportHideThreshold = threshold
+}
+
+// CreateAbuseReport принимает жалобу пользователя на заявку, комментарий или другого пользователя
+func (s *RequestService) CreateAbuseReport(userID int, input models.AbuseReportInput) (*models.AbuseReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if !input.TargetType.IsValid() {
+		return nil, fmt.Errorf("%w: unknown target type %q", models.ErrInvalidRequest, input.TargetType)
+	}
+	if !input.Reason.IsValid() {
+		return nil, fmt.Errorf("%w: unknown reason %q", models.ErrInvalidRequest, input.Reason)
+	}
+	details := strings.TrimSpace(input.Details)
+	if len([]rune(details)) > maxAbuseReportDetailsLength {
+		return nil, fmt.Errorf("%w: details must be at most %d characters", models.ErrInvalidRequest, maxAbuseReportDetailsLength)
+	}
+	if input.Reason == models.AbuseReportReasonOther && details == "" {
+		return nil, fmt.Errorf("%w: details are required for reason other", models.ErrInvalidRequest)
+	}
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	ownerID, err := s.repo.Request.GetAbuseReportTargetOwner(ctx, input.TargetType, input.TargetID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	if ownerID == userID {
+		return nil, fmt.Errorf("%w: you cannot report yourself or your own content", models.ErrInvalidRequest)
+	}
+
+	report, hidden, err := s.repo.Request.CreateAbuseReport(ctx, &models.AbuseReport{
+		ReporterID: userID,
+		TargetType: input.TargetType,
+		TargetID:   input.TargetID,
+		Reason:     input.Reason,
+		Details:    details,
+	}, s.reportHideThreshold)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, models.ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return nil, fmt.Errorf("%w: you have already reported this", models.ErrConflict)
+		}
+		return nil, err
+	}
+
+	if hidden {
+		s.logger.WithField("target_type", input.TargetType).
+			WithField("target_id", input.TargetID).
+			Info("Reported content hidden until review")
+	}
+
+	return report, nil
+}
+
+// GetAbuseReports возвращает жалобы в статусе status, самые давние первыми
+func (s *RequestService) GetAbuseReports(status models.AbuseReportStatus, limit, offset int) ([]models.AbuseReport, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch status {
+	case models.AbuseReportOpen, models.AbuseReportDismissed, models.AbuseReportActioned:
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown report status %q", models.ErrInvalidRequest, status)
+	}
+
+	return s.repo.Request.GetAbuseReports(ctx, status, limit, offset)
+}
+
+// ResolveAbuseReport применяет решение администратора ко всем открытым жалобам на объект жалобы
+func (s *RequestService) ResolveAbuseReport(adminID, reportID int, input models.AbuseReportResolveInput) (*models.AbuseReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	switch input.Action {
+	case models.AbuseReportActionDismiss, models.AbuseReportActionHide, models.AbuseReportActionSuspend:
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", models.ErrInvalidRequest, input.Action)
+	}
+	input.Note = strings.TrimSpace(input.Note)
+	if len([]rune(input.Note)) > maxAbuseReportDetailsLength {
+		return nil, fmt.Errorf("%w: note must be at most %d characters", models.ErrInvalidRequest, maxAbuseReportDetailsLength)
+	}
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	report, err := s.repo.Request.ResolveAbuseReport(ctx, reportID, adminID, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, models.ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return nil, fmt.Errorf("%w: report is already resolved", models.ErrConflict)
+		case errors.Is(err, requestrepo.ErrNotHideable):
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidRequest, err)
+		}
+		return nil, err
+	}
+
+	s.logger.WithField("admin_id", adminID).
+		WithField("report_id", reportID).
+		WithField("action", input.Action).
+		Info("Abuse report resolved")
+
+	return report, nil
+}
+
+// UnsuspendUser снимает блокировку пользователя
+func (s *RequestService) UnsuspendUser(adminID, userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return err
+	}
+
+	if err := s.repo.Request.UnsuspendUser(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// requireActiveUser проверяет, что пользователь не заблокирован по жалобам
+func (s *RequestService) requireActiveUser(ctx context.Context, userID int) error {
+	suspended, err := s.repo.Request.IsUserSuspended(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+	if suspended {
+		return fmt.Errorf("%w: your account is suspended", models.ErrForbidden)
+	}
+
+	return nil
+}
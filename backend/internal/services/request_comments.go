@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// maxCommentLength - максимальная длина комментария в символах
+const maxCommentLength = 1000
+
+// GetRequestComments возвращает страницу веток комментариев заявки и общее число веток.
+// Ветка - комментарий верхнего уровня со всеми ответами на него.
+func (s *RequestService) GetRequestComments(userID, requestID, limit, offset int) ([]models.RequestComment, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, 0, models.ErrNotFound
+		}
+		return nil, 0, err
+	}
+
+	if err := s.requireRequestVisible(ctx, userID, request.RequesterID, request.Status, request.Hidden); err != nil {
+		return nil, 0, err
+	}
+
+	return s.repo.Request.GetRequestComments(ctx, requestID, limit, offset)
+}
+
+// AddComment добавляет комментарий к запросу. Ответ на ответ добавляется
+// в ветку исходного комментария, поэтому вложенность не превышает одного уровня.
+func (s *RequestService) AddComment(userID, requestID int, input models.RequestCommentInput) (*models.RequestComment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := validateCommentText(input.Text); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Проверка существования запроса
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.requireRequestVisible(ctx, userID, request.RequesterID, request.Status, request.Hidden); err != nil {
+		return nil, err
+	}
+
+	// Писать нельзя, если автор заявки или автор комментария, на который отвечают, внес пользователя в черный список
+	blockerIDs := []int{request.RequesterID}
+
+	comment := models.RequestComment{
+		RequestID: requestID,
+		UserID:    userID,
+		Text:      input.Text,
+	}
+
+	if input.ParentID != nil {
+		parent, err := s.repo.Request.GetCommentByID(ctx, requestID, *input.ParentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, fmt.Errorf("%w: parent comment %d not found", models.ErrInvalidRequest, *input.ParentID)
+			}
+			return nil, err
+		}
+		if parent.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: cannot reply to a deleted comment", models.ErrInvalidRequest)
+		}
+		if parent.Hidden {
+			return nil, fmt.Errorf("%w: cannot reply to a hidden comment", models.ErrInvalidRequest)
+		}
+
+		parentID := parent.ID
+		if parent.ParentID != nil {
+			parentID = *parent.ParentID
+		}
+		comment.ParentID = &parentID
+		blockerIDs = append(blockerIDs, parent.UserID)
+	}
+
+	blocked, err := s.repo.Request.IsBlockedByAny(ctx, blockerIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, fmt.Errorf("%w: you have been blocked by the author", models.ErrForbidden)
+	}
+
+	createdComment, err := s.repo.Request.AddComment(ctx, &comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	// Добавление опыта за комментарий
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.gameService.AddExperience(ctx, userID, 5, "add_comment"); err != nil {
+			s.logger.WithError(err).Error("Failed to add experience for comment")
+		}
+	}()
+
+	return createdComment, nil
+}
+
+// UpdateComment изменяет текст комментария. Доступно только автору комментария.
+func (s *RequestService) UpdateComment(userID, requestID, commentID int, input models.RequestCommentUpdateInput) (*models.RequestComment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := validateCommentText(input.Text); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getLiveComment(ctx, requestID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, fmt.Errorf("%w: only the author can edit a comment", models.ErrForbidden)
+	}
+
+	updated, err := s.repo.Request.UpdateComment(ctx, requestID, commentID, input.Text)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// DeleteComment удаляет комментарий. Автор удаляет свой комментарий, администратор - любой;
+// удаленный администратором комментарий остается в ветке отметкой "удален модератором".
+func (s *RequestService) DeleteComment(userID, requestID, commentID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	comment, err := s.getLiveComment(ctx, requestID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID {
+		if err := s.requireAdmin(ctx, userID); err != nil {
+			if errors.Is(err, models.ErrForbidden) {
+				return fmt.Errorf("%w: only the author or an administrator can delete a comment", models.ErrForbidden)
+			}
+			return err
+		}
+	}
+
+	if err := s.repo.Request.DeleteComment(ctx, requestID, commentID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	if comment.UserID != userID {
+		s.logger.WithField("comment_id", commentID).
+			WithField("request_id", requestID).
+			WithField("admin_id", userID).
+			Info("Comment removed by moderator")
+	}
+
+	return nil
+}
+
+// getLiveComment возвращает неудаленный комментарий заявки
+func (s *RequestService) getLiveComment(ctx context.Context, requestID, commentID int) (*models.RequestComment, error) {
+	comment, err := s.repo.Request.GetCommentByID(ctx, requestID, commentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	if comment.DeletedAt != nil {
+		return nil, models.ErrNotFound
+	}
+
+	return comment, nil
+}
+
+// validateCommentText проверяет, что текст комментария не пустой и не длиннее maxCommentLength
+func validateCommentText(text string) error {
+	length := len([]rune(strings.TrimSpace(text)))
+	if length == 0 || length > maxCommentLength {
+		return fmt.Errorf("%w: comment text must be from 1 to %d characters", models.ErrInvalidRequest, maxCommentLength)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+	"moshosp/backend/internal/repository/requestrepo"
+)
+
+// defaultMaxVolunteerActiveRequests - сколько незавершенных заявок координатор может назначить одному волонтеру
+const defaultMaxVolunteerActiveRequests = 5
+
+// SetMaxVolunteerActiveRequests задает, сколько незавершенных заявок координатор может назначить одному волонтеру
+func (s *RequestService) SetMaxVolunteerActiveRequests(limit int) {
+	s.maxVolunteerActiveRequests = limit
+}
+
+// AssignRequest назначает волонтера на свободное место заявки. Доступно только администраторам.
+// Волонтер и автор заявки получают уведомления.
+func (s *RequestService) AssignRequest(adminID, requestID int, input models.RequestAssignInput) (models.RequestFullInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	return s.assignRequest(ctx, adminID, requestID, input.VolunteerID)
+}
+
+// BulkAssignRequests назначает одного волонтера на несколько заявок. Доступно только администраторам.
+// Заявки назначаются по очереди и независимо, результат по каждой возвращается отдельно.
+func (s *RequestService) BulkAssignRequests(adminID int, input models.RequestBulkAssignInput) ([]models.RequestBulkAssignResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if len(input.RequestIDs) == 0 || len(input.RequestIDs) > 50 {
+		return nil, fmt.Errorf("%w: requestIds must contain from 1 to 50 requests", models.ErrInvalidRequest)
+	}
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	results := make([]models.RequestBulkAssignResult, 0, len(input.RequestIDs))
+	for _, requestID := range input.RequestIDs {
+		result := models.RequestBulkAssignResult{RequestID: requestID}
+
+		request, err := s.assignRequest(ctx, adminID, requestID, input.VolunteerID)
+		switch {
+		case errors.Is(err, models.ErrNotFound), errors.Is(err, models.ErrConflict), errors.Is(err, models.ErrInvalidRequest):
+			result.Error = err.Error()
+		case err != nil:
+			s.logger.WithError(err).WithField("request_id", requestID).Error("Failed to assign request")
+			result.Error = models.ErrInternalError.Error()
+		default:
+			result.Request = &request
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// ReassignRequest передает место в заявке от одного волонтера другому. Доступно только администраторам.
+// Оба волонтера и автор заявки получают уведомления.
+func (s *RequestService) ReassignRequest(adminID, requestID int, input models.RequestReassignInput) (models.RequestFullInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if input.FromVolunteerID == input.ToVolunteerID {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: volunteers must differ", models.ErrInvalidRequest)
+	}
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	if err := s.checkAssignableVolunteer(ctx, input.ToVolunteerID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+	if err := s.checkDispatchBlock(ctx, requestID, input.ToVolunteerID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	request, err := s.repo.Request.ReassignRequest(ctx, requestID, input.FromVolunteerID, input.ToVolunteerID, s.maxVolunteerActiveRequests)
+	if err != nil {
+		return models.RequestFullInfo{}, s.dispatchError(err)
+	}
+	s.closeVolunteerTimeEntry(ctx, requestID, input.FromVolunteerID)
+	// Статус заявки при передаче не меняется, событие сообщает клиентам нового волонтера
+	s.publishRequestStatus(ctx, request, "")
+
+	reason := ""
+	if input.Reason != "" {
+		reason = fmt.Sprintf(" Причина: %s.", input.Reason)
+	}
+
+	s.notify(ctx, input.ToVolunteerID, models.NotificationTypeRequestAssigned, requestID,
+		"Вам назначена заявка",
+		fmt.Sprintf("Координатор передал вам заявку «%s».%s", request.Title, reason))
+	s.notify(ctx, input.FromVolunteerID, models.NotificationTypeRequestAssigned, requestID,
+		"Заявка передана другому волонтеру",
+		fmt.Sprintf("Координатор передал заявку «%s» другому волонтеру.%s", request.Title, reason))
+	s.notify(ctx, request.RequesterID, models.NotificationTypeRequestAssigned, requestID,
+		"У заявки новый волонтер",
+		fmt.Sprintf("Координатор назначил другого волонтера на заявку «%s».", request.Title))
+
+	return s.getRequestFullInfo(ctx, requestID)
+}
+
+// GetVolunteerLoads возвращает волонтеров с числом их незавершенных заявок. Доступно только администраторам.
+func (s *RequestService) GetVolunteerLoads(adminID int) ([]models.VolunteerLoad, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Request.GetVolunteerLoads(ctx)
+}
+
+// assignRequest проверяет волонтера, закрепляет его за заявкой и рассылает уведомления
+func (s *RequestService) assignRequest(ctx context.Context, adminID, requestID, volunteerID int) (models.RequestFullInfo, error) {
+	if err := s.checkAssignableVolunteer(ctx, volunteerID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+	if err := s.checkDispatchBlock(ctx, requestID, volunteerID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	request, err := s.repo.Request.AssignRequest(ctx, requestID, volunteerID, adminID, s.maxVolunteerActiveRequests)
+	if err != nil {
+		return models.RequestFullInfo{}, s.dispatchError(err)
+	}
+	s.publishRequestStatus(ctx, request, models.RequestStatusNew)
+
+	s.notify(ctx, volunteerID, models.NotificationTypeRequestAssigned, requestID,
+		"Вам назначена заявка",
+		fmt.Sprintf("Координатор назначил вас на заявку «%s».", request.Title))
+	s.notify(ctx, request.RequesterID, models.NotificationTypeRequestAssigned, requestID,
+		"На заявку назначен волонтер",
+		fmt.Sprintf("Координатор назначил волонтера на заявку «%s».", request.Title))
+
+	return s.getRequestFullInfo(ctx, requestID)
+}
+
+// checkDispatchBlock проверяет, что автор заявки не внес назначаемого волонтера в черный список
+func (s *RequestService) checkDispatchBlock(ctx context.Context, requestID, volunteerID int) error {
+	blocked, err := s.isBlockedByRequester(ctx, requestID, volunteerID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: volunteer is blocked by the request author", models.ErrConflict)
+	}
+
+	return nil
+}
+
+// dispatchError приводит ошибку назначения из репозитория к ошибке сервиса
+func (s *RequestService) dispatchError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return models.ErrNotFound
+	case errors.Is(err, requestrepo.ErrUserNotFound):
+		return fmt.Errorf("%w: volunteer not found", models.ErrInvalidRequest)
+	case errors.Is(err, requestrepo.ErrVolunteerBusy):
+		return fmt.Errorf("%w: volunteer already has %d active requests", models.ErrConflict, s.maxVolunteerActiveRequests)
+	case errors.Is(err, requestrepo.ErrNotAssigned):
+		return fmt.Errorf("%w: %v", models.ErrConflict, err)
+	case errors.Is(err, repository.ErrConflict):
+		return fmt.Errorf("%w: request is closed, hidden, full or already has this volunteer", models.ErrConflict)
+	}
+	return fmt.Errorf("failed to dispatch request: %w", err)
+}
+
+// requireAdmin возвращает models.ErrForbidden, если пользователь не администратор
+func (s *RequestService) requireAdmin(ctx context.Context, userID int) error {
+	user, err := s.repo.User.GetUserByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.UserRoleAdmin {
+		return models.ErrForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+	"moshosp/backend/internal/repository/requestrepo"
+)
+
+const (
+	// escalationBatchSize - сколько заявок эскалируется за один запуск задачи
+	escalationBatchSize = 100
+	// escalationVolunteersLimit - сколько волонтеров категории уведомляется об эскалации
+	escalationVolunteersLimit = 20
+)
+
+// raisedPriority возвращает приоритет на одну ступень выше; высокий приоритет не меняется
+func raisedPriority(priority models.RequestPriority) models.RequestPriority {
+	switch priority {
+	case models.RequestPriorityLow:
+		return models.RequestPriorityMedium
+	case models.RequestPriorityMedium:
+		return models.RequestPriorityHigh
+	default:
+		return priority
+	}
+}
+
+// EscalateOverdueRequests эскалирует заявки без волонтера, ожидание которых превысило
+// порог правила их категории и приоритета: повышает приоритет, если это разрешено правилом,
+// и уведомляет администраторов и опытных волонтеров категории.
+// Каждая заявка эскалируется не более maxLevel раз. Вызывается планировщиком фоновых задач.
+func (s *RequestService) EscalateOverdueRequests(ctx context.Context, maxLevel int) error {
+	now := time.Now()
+
+	candidates, err := s.repo.Request.GetEscalationCandidates(ctx, now, maxLevel, escalationBatchSize)
+	if err != nil {
+		return err
+	}
+
+	escalated := 0
+	for i := range candidates {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := s.escalateRequest(ctx, &candidates[i], now); err != nil {
+			// Заявку успели взять, изменить или эскалировать в другом экземпляре сервера
+			if !errors.Is(err, repository.ErrConflict) && !errors.Is(err, repository.ErrNotFound) {
+				s.logger.WithError(err).WithField("request_id", candidates[i].RequestID).Error("Failed to escalate request")
+			}
+			continue
+		}
+		escalated++
+	}
+
+	if escalated > 0 {
+		s.logger.WithField("count", escalated).Info("Escalated unassigned requests")
+	}
+
+	return nil
+}
+
+// escalateRequest записывает эскалацию одной заявки и рассылает уведомления
+func (s *RequestService) escalateRequest(ctx context.Context, candidate *models.EscalationCandidate, now time.Time) error {
+	recipients, err := s.repo.Request.GetEscalationRecipients(ctx, candidate.RequesterID, candidate.CategoryID, escalationVolunteersLimit)
+	if err != nil {
+		return err
+	}
+
+	toPriority := candidate.Priority
+	if candidate.RaisePriority {
+		toPriority = raisedPriority(candidate.Priority)
+	}
+
+	ruleID := candidate.RuleID
+	escalation, err := s.repo.Request.EscalateRequest(ctx, &models.RequestEscalation{
+		RequestID:        candidate.RequestID,
+		RuleID:           &ruleID,
+		Level:            candidate.Level + 1,
+		FromPriority:     candidate.Priority,
+		ToPriority:       toPriority,
+		ThresholdMinutes: candidate.ThresholdMinutes,
+		WaitedMinutes:    int(now.Sub(candidate.CreatedAt).Minutes()),
+		NotifiedCount:    len(recipients),
+	})
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Заявка «%s» ждет волонтера уже %s", candidate.Title, formatWaitDuration(escalation.WaitedMinutes))
+	if escalation.ToPriority != escalation.FromPriority {
+		message += fmt.Sprintf(", приоритет повышен до %s", escalation.ToPriority)
+	}
+
+	for _, userID := range recipients {
+		s.notify(ctx, userID, models.NotificationTypeRequestEscalated, candidate.RequestID, "Заявка ждет волонтера", message)
+	}
+
+	return nil
+}
+
+// formatWaitDuration форматирует время ожидания заявки для уведомления
+func formatWaitDuration(minutes int) string {
+	switch {
+	case minutes >= 24*60:
+		return fmt.Sprintf("%d д. %d ч.", minutes/(24*60), minutes%(24*60)/60)
+	case minutes >= 60:
+		return fmt.Sprintf("%d ч. %d мин.", minutes/60, minutes%60)
+	default:
+		return fmt.Sprintf("%d мин.", minutes)
+	}
+}
+
+// GetEscalationRules возвращает правила эскалации заявок
+func (s *RequestService) GetEscalationRules() ([]models.EscalationRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.repo.Request.GetEscalationRules(ctx)
+}
+
+// SaveEscalationRule создает правило эскалации или заменяет правило с той же категорией и приоритетом
+func (s *RequestService) SaveEscalationRule(input models.EscalationRuleInput) (*models.EscalationRule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch input.Priority {
+	case models.RequestPriorityLow, models.RequestPriorityMedium, models.RequestPriorityHigh:
+	default:
+		return nil, fmt.Errorf("%w: priority must be low, medium or high", models.ErrInvalidRequest)
+	}
+	if input.ThresholdMinutes < 5 {
+		return nil, fmt.Errorf("%w: thresholdMinutes must be at least 5", models.ErrInvalidRequest)
+	}
+
+	rule, err := s.repo.Request.SaveEscalationRule(ctx, &models.EscalationRule{
+		CategoryID:       input.CategoryID,
+		Priority:         input.Priority,
+		ThresholdMinutes: input.ThresholdMinutes,
+		RaisePriority:    input.RaisePriority,
+	})
+	if err != nil {
+		if errors.Is(err, requestrepo.ErrCategoryNotFound) {
+			return nil, fmt.Errorf("%w: category not found", models.ErrInvalidRequest)
+		}
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteEscalationRule удаляет правило эскалации
+func (s *RequestService) DeleteEscalationRule(ruleID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.repo.Request.DeleteEscalationRule(ctx, ruleID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetRequestEscalations возвращает журнал эскалаций заявки
+func (s *RequestService) GetRequestEscalations(requestID int) ([]models.RequestEscalation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.repo.Request.GetRequestByID(ctx, requestID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s.repo.Request.GetRequestEscalations(ctx, requestID)
+}
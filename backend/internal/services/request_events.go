@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/events"
+)
+
+// SetEventHub задает Hub, в который публикуются события потока: уведомления,
+// смены статусов и новые заявки. Без него события не публикуются.
+func (s *RequestService) SetEventHub(hub *events.Hub) {
+	s.events = hub
+}
+
+// publishNotification отправляет созданное уведомление в поток его получателя
+func (s *RequestService) publishNotification(notification *models.Notification) {
+	if s.events == nil || notification == nil {
+		return
+	}
+	s.events.Publish(events.Event{
+		Type:   events.TypeNotification,
+		Data:   notification,
+		UserID: notification.UserID,
+	})
+}
+
+// publishRequestStatus публикует смену статуса заявки; from пуст, если прежний статус неизвестен.
+// Смена статуса заявки, скрытой из ленты или по жалобам, отправляется только ее автору.
+// Заявка, впервые появившаяся в ленте, публикуется еще и как новая.
+func (s *RequestService) publishRequestStatus(ctx context.Context, request *models.HelpRequest, from models.RequestStatus) {
+	if s.events == nil || request == nil || from == request.Status {
+		return
+	}
+
+	userID := 0
+	if !request.Status.IsFeedVisible() || request.Hidden {
+		userID = request.RequesterID
+	}
+	s.events.Publish(events.Event{
+		Type: events.TypeRequestStatus,
+		Data: models.RequestStatusEvent{
+			RequestID:      request.ID,
+			Status:         request.Status,
+			PreviousStatus: from,
+			AssignedTo:     request.AssignedTo,
+		},
+		UserID: userID,
+	})
+
+	if request.Status == models.RequestStatusNew && !request.Hidden && from != "" && !from.IsFeedVisible() {
+		s.publishRequestCreated(ctx, request.ID)
+	}
+}
+
+// publishRequestCreated публикует заявку, появившуюся в ленте: созданную,
+// одобренную модератором, импортированную или повторение серии
+func (s *RequestService) publishRequestCreated(ctx context.Context, requestID int) {
+	if s.events == nil {
+		return
+	}
+
+	request, err := s.repo.Request.GetRequestFullInfo(ctx, requestID)
+	if err != nil {
+		s.logger.WithError(err).WithField("request_id", requestID).Error("Failed to load request for event")
+		return
+	}
+	if request.Status != models.RequestStatusNew || request.Hidden {
+		return
+	}
+
+	s.events.Publish(events.Event{
+		Type:     events.TypeRequestCreated,
+		Data:     request,
+		Category: request.CategoryName,
+		Priority: string(request.Priority),
+	})
+}
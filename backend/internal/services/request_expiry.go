@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// expiryBatchSize - сколько заявок истекает в одной транзакции
+const expiryBatchSize = 100
+
+// ExpireOverdueRequests переводит заявки, не набравшие волонтеров к сроку, в статус "expired"
+// и уведомляет их авторов и уже закрепленных волонтеров. Вызывается планировщиком фоновых задач.
+func (s *RequestService) ExpireOverdueRequests(ctx context.Context) error {
+	now := time.Now()
+	total := 0
+
+	for {
+		expired, err := s.repo.Request.ExpireOverdueRequests(ctx, now, expiryBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range expired {
+			request := &expired[i]
+			s.closeRequestTimeEntries(ctx, request.ID)
+			s.publishRequestStatus(ctx, request, models.RequestStatusNew)
+			s.notify(ctx, request.RequesterID, models.NotificationTypeRequestExpired, request.ID,
+				"Срок заявки истек",
+				fmt.Sprintf("К сроку не нашлось волонтеров для заявки «%s». Вы можете создать ее заново.", request.Title))
+			s.notifyExpiredRequestVolunteers(ctx, request)
+		}
+
+		total += len(expired)
+		if len(expired) < expiryBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.logger.WithField("count", total).Info("Expired overdue requests")
+	}
+
+	return nil
+}
+
+// notifyExpiredRequestVolunteers уведомляет волонтеров, занявших часть мест истекшей заявки
+func (s *RequestService) notifyExpiredRequestVolunteers(ctx context.Context, request *models.HelpRequest) {
+	if err := s.loadRequestVolunteerIDs(ctx, request); err != nil {
+		s.logger.WithError(err).WithField("request_id", request.ID).Error("Failed to notify volunteers of expired request")
+		return
+	}
+
+	for _, volunteerID := range request.VolunteerIDs {
+		s.notify(ctx, volunteerID, models.NotificationTypeRequestExpired, request.ID,
+			"Срок заявки истек",
+			fmt.Sprintf("К сроку заявке «%s» не хватило волонтеров, и она закрыта. Помогать по ней больше не нужно.", request.Title))
+	}
+}
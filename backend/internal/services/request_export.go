@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/export"
+)
+
+// exportTimeout ограничивает время одной выгрузки
+const exportTimeout = 5 * time.Minute
+
+// ExportRequests записывает в out заявки под фильтр в выбранном формате.
+// Заявки пишутся по мере чтения из базы, пагинация фильтра не учитывается.
+// Права проверяются до записи первого байта. Доступно только администраторам.
+func (s *RequestService) ExportRequests(ctx context.Context, adminID int, filter models.RequestFilter, format export.Format, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return err
+	}
+
+	w, err := export.NewWriter(format, out, "Заявки")
+	if err != nil {
+		return err
+	}
+
+	err = w.WriteRow("ID", "Название", "Статус", "Категория", "Приоритет", "Адрес",
+		"Создана", "Выполнена", "Срок", "Автор", "Волонтер", "Мест", "Организация")
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Request.ExportRequests(ctx, filter, func(request *models.RequestFullInfo) error {
+		return w.WriteRow(
+			request.ID,
+			request.Title,
+			string(request.Status),
+			request.CategoryName,
+			string(request.Priority),
+			request.Location,
+			request.CreatedAt,
+			request.CompletedAt,
+			request.DueAt,
+			exportName(request.RequesterFirstName, request.RequesterLastName, request.RequesterUsername),
+			exportVolunteerName(request),
+			request.VolunteerSlots,
+			request.PartnerName,
+		)
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("admin_id", adminID).Error("Failed to export requests")
+		return err
+	}
+
+	return w.Close()
+}
+
+// ExportVolunteerActivity записывает в out активность волонтеров за период:
+// выполненные заявки, засчитанные часы и оценки от авторов заявок.
+// Доступно только администраторам.
+func (s *RequestService) ExportVolunteerActivity(ctx context.Context, adminID int, filter models.VolunteerActivityFilter, format export.Format, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return err
+	}
+
+	if !filter.From.Before(filter.To) {
+		return fmt.Errorf("%w: period start must be before its end", models.ErrInvalidRequest)
+	}
+
+	w, err := export.NewWriter(format, out, "Активность волонтеров")
+	if err != nil {
+		return err
+	}
+
+	err = w.WriteRow("ID", "Волонтер", "Логин", "Выполнено заявок", "Часы", "Оценок", "Средняя оценка")
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Request.ExportVolunteerActivity(ctx, filter, func(activity *models.VolunteerActivity) error {
+		return w.WriteRow(
+			activity.VolunteerID,
+			exportName(activity.FirstName, activity.LastName, activity.Username),
+			activity.Username,
+			activity.CompletedRequests,
+			math.Round(float64(activity.Minutes)/60*100)/100,
+			activity.RatingsCount,
+			activity.AverageRating,
+		)
+	})
+	if err != nil {
+		s.logger.WithError(err).WithField("admin_id", adminID).Error("Failed to export volunteer activity")
+		return err
+	}
+
+	return w.Close()
+}
+
+// exportName возвращает имя и фамилию пользователя, а если их нет - логин
+func exportName(firstName, lastName, username string) string {
+	name := strings.TrimSpace(firstName + " " + lastName)
+	if name == "" {
+		return username
+	}
+	return name
+}
+
+// exportVolunteerName возвращает имя основного волонтера заявки или пустую строку
+func exportVolunteerName(request *models.RequestFullInfo) string {
+	if request.AssignedTo == nil {
+		return ""
+	}
+	var firstName, lastName, username string
+	if request.VolunteerFirstName != nil {
+		firstName = *request.VolunteerFirstName
+	}
+	if request.VolunteerLastName != nil {
+		lastName = *request.VolunteerLastName
+	}
+	if request.VolunteerUsername != nil {
+		username = *request.VolunteerUsername
+	}
+	return exportName(firstName, lastName, username)
+}
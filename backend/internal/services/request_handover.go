@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+	"moshosp/backend/internal/repository/requestrepo"
+)
+
+// ReleaseRequest снимает волонтера с взятой заявки с обязательной причиной.
+// Без передачи место освобождается сразу, заявка возвращается в поиск волонтеров,
+// а ее автор получает уведомление. Если указан input.HandoverTo, создается передача
+// заявки этому волонтеру; до его согласия передающий волонтер остается закрепленным.
+func (s *RequestService) ReleaseRequest(userID, requestID int, input models.RequestReleaseInput) (models.RequestFullInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if input.Reason == "" {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: reason is required", models.ErrInvalidRequest)
+	}
+
+	request, err := s.getAssignedRequest(ctx, userID, requestID)
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	if input.HandoverTo != nil {
+		if err := s.createRequestHandover(ctx, request, userID, *input.HandoverTo, input.Reason); err != nil {
+			return models.RequestFullInfo{}, err
+		}
+		return s.getRequestFullInfo(ctx, requestID)
+	}
+
+	released, err := s.repo.Request.ReleaseRequest(ctx, requestID, userID, input.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return models.RequestFullInfo{}, models.ErrNotFound
+		case errors.Is(err, requestrepo.ErrNotAssigned):
+			return models.RequestFullInfo{}, fmt.Errorf("%w: %v", models.ErrForbidden, err)
+		case errors.Is(err, repository.ErrConflict):
+			return models.RequestFullInfo{}, fmt.Errorf("%w: request is already closed", models.ErrConflict)
+		}
+		return models.RequestFullInfo{}, fmt.Errorf("failed to release request: %w", err)
+	}
+	s.closeVolunteerTimeEntry(ctx, requestID, userID)
+	s.publishRequestStatus(ctx, released, request.Status)
+
+	s.notify(ctx, request.RequesterID, models.NotificationTypeRequestReleased, request.ID,
+		"Волонтер отказался от заявки",
+		fmt.Sprintf("Волонтер отказался от заявки «%s». Причина: %s. Заявка снова ищет волонтера.", request.Title, input.Reason))
+
+	return s.getRequestFullInfo(ctx, requestID)
+}
+
+// createRequestHandover проверяет волонтера, которому передается заявка, и создает передачу
+func (s *RequestService) createRequestHandover(ctx context.Context, request *models.HelpRequest, fromID, toID int, reason string) error {
+	if toID == fromID {
+		return fmt.Errorf("%w: cannot hand over a request to yourself", models.ErrInvalidRequest)
+	}
+	if request.Status != models.RequestStatusNew && request.Status != models.RequestStatusInProgress {
+		return fmt.Errorf("%w: request is already closed", models.ErrConflict)
+	}
+	if isRequestVolunteer(request, toID) {
+		return fmt.Errorf("%w: volunteer is already assigned to this request", models.ErrConflict)
+	}
+
+	if err := s.checkAssignableVolunteer(ctx, toID); err != nil {
+		return err
+	}
+
+	blocked, err := s.repo.Request.IsBlockedByAny(ctx, []int{request.RequesterID}, toID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return fmt.Errorf("%w: volunteer is blocked by the request author", models.ErrConflict)
+	}
+
+	_, err = s.repo.Request.CreateRequestHandover(ctx, &models.RequestHandover{
+		RequestID:       request.ID,
+		FromVolunteerID: fromID,
+		ToVolunteerID:   &toID,
+		Reason:          reason,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return fmt.Errorf("%w: request already has a pending handover", models.ErrConflict)
+		}
+		return fmt.Errorf("failed to create request handover: %w", err)
+	}
+
+	s.notify(ctx, toID, models.NotificationTypeRequestHandover, request.ID,
+		"Вам передают заявку",
+		fmt.Sprintf("Волонтер хочет передать вам заявку «%s». Причина: %s. Примите или отклоните передачу.", request.Title, reason))
+
+	return nil
+}
+
+// AcceptRequestHandover принимает передачу заявки: пользователь занимает место передающего волонтера
+func (s *RequestService) AcceptRequestHandover(userID, requestID int) (models.RequestFullInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	handover, err := s.getIncomingRequestHandover(ctx, userID, requestID)
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	// Автор мог внести волонтера в черный список уже после создания передачи
+	blocked, err := s.isBlockedByRequester(ctx, requestID, userID)
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+	if blocked {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: the request author has blocked you", models.ErrForbidden)
+	}
+
+	request, err := s.repo.Request.AcceptRequestHandover(ctx, handover.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return models.RequestFullInfo{}, models.ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return models.RequestFullInfo{}, fmt.Errorf("%w: handover is no longer available", models.ErrConflict)
+		}
+		return models.RequestFullInfo{}, fmt.Errorf("failed to accept request handover: %w", err)
+	}
+	s.closeVolunteerTimeEntry(ctx, requestID, handover.FromVolunteerID)
+
+	s.notify(ctx, handover.FromVolunteerID, models.NotificationTypeRequestHandover, requestID,
+		"Передача заявки принята",
+		fmt.Sprintf("Заявку «%s» принял другой волонтер.", request.Title))
+	s.notify(ctx, request.RequesterID, models.NotificationTypeRequestReleased, requestID,
+		"У заявки новый волонтер",
+		fmt.Sprintf("Заявку «%s» передали другому волонтеру. Причина: %s.", request.Title, handover.Reason))
+
+	return s.getRequestFullInfo(ctx, requestID)
+}
+
+// DeclineRequestHandover отклоняет передачу заявки; передающий волонтер остается закрепленным
+func (s *RequestService) DeclineRequestHandover(userID, requestID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	handover, err := s.getIncomingRequestHandover(ctx, userID, requestID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Request.DeclineRequestHandover(ctx, handover.ID); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return fmt.Errorf("%w: handover is no longer available", models.ErrConflict)
+		}
+		return fmt.Errorf("failed to decline request handover: %w", err)
+	}
+
+	s.notify(ctx, handover.FromVolunteerID, models.NotificationTypeRequestHandover, requestID,
+		"Передача заявки отклонена",
+		"Волонтер отказался принять заявку. Заявка остается за вами, вы можете освободить ее без передачи.")
+
+	return nil
+}
+
+// getIncomingRequestHandover получает ожидающую передачу заявки, адресованную пользователю
+func (s *RequestService) getIncomingRequestHandover(ctx context.Context, userID, requestID int) (*models.RequestHandover, error) {
+	handover, err := s.repo.Request.GetPendingRequestHandover(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: request has no pending handover", models.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	if handover.ToVolunteerID == nil || *handover.ToVolunteerID != userID {
+		return nil, models.ErrForbidden
+	}
+
+	return handover, nil
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// ImportRequests импортирует заявки из CSV. Сначала проверяются все строки; если хотя бы
+// в одной есть ошибка или запрошен проверочный прогон, заявки не создаются и возвращается отчет.
+// Иначе все заявки создаются в одной транзакции. Строки с уже импортированным внешним ID
+// пропускаются, поэтому повторная загрузка того же файла ничего не дублирует.
+// Как и при создании заявки автором, заявки заблокированных авторов не принимаются,
+// а заявки авторов, которым нужна проверка, создаются в статусе "pending_review".
+// Доступно только администраторам.
+func (s *RequestService) ImportRequests(adminID int, data []byte, dryRun bool) (*models.RequestImportReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	rows, err := parseRequestImport(data)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.validateImportRows(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	imported, err := s.repo.Request.GetImportedRequestIDs(ctx, importExternalIDs(rows))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]models.RequestStatus, len(items))
+	for _, item := range items {
+		statuses[item.ExternalID] = item.Request.Status
+	}
+
+	report := &models.RequestImportReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]models.RequestImportRowResult, len(rows)),
+	}
+	for i, row := range rows {
+		result := models.RequestImportRowResult{
+			Line:       row.Line,
+			ExternalID: row.ExternalID,
+			Status:     models.RequestImportRowValid,
+		}
+		// Уже импортированная строка пропускается, даже если ее значения устарели,
+		// например срок прошел: повторная загрузка файла не должна завершаться ошибкой
+		switch {
+		case imported[row.ExternalID] != 0:
+			id := imported[row.ExternalID]
+			result.Status = models.RequestImportRowSkipped
+			result.RequestID = &id
+			report.Skipped++
+		case len(row.Errors) > 0:
+			result.Status = models.RequestImportRowInvalid
+			result.Errors = row.Errors
+			report.Invalid++
+		default:
+			result.RequestStatus = statuses[row.ExternalID]
+		}
+		report.Rows[i] = result
+	}
+
+	if dryRun || report.Invalid > 0 {
+		return report, nil
+	}
+
+	pending := make([]models.RequestImportItem, 0, len(items))
+	for _, item := range items {
+		if imported[item.ExternalID] == 0 {
+			pending = append(pending, item)
+		}
+	}
+
+	created, err := s.repo.Request.ImportRequests(ctx, adminID, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range report.Rows {
+		result := &report.Rows[i]
+		if result.Status != models.RequestImportRowValid {
+			continue
+		}
+		// Строку без созданной заявки успел импортировать параллельный запрос
+		if id, ok := created[result.ExternalID]; ok {
+			result.Status = models.RequestImportRowCreated
+			result.RequestID = &id
+			report.Created++
+			s.publishRequestCreated(ctx, id)
+		} else {
+			result.Status = models.RequestImportRowSkipped
+			report.Skipped++
+		}
+	}
+
+	s.logger.WithField("admin_id", adminID).
+		WithField("created", report.Created).
+		WithField("skipped", report.Skipped).
+		Info("Requests imported")
+
+	return report, nil
+}
+
+// validateImportRows проверяет значения строк импорта, находит категории и авторов заявок.
+// Ошибки добавляются в Errors строк; для строк без ошибок возвращаются готовые заявки
+// в начальном статусе их автора.
+func (s *RequestService) validateImportRows(ctx context.Context, rows []models.RequestImportRow) ([]models.RequestImportItem, error) {
+	categories, err := s.repo.Request.GetCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	categoryIDs := make(map[string]int, len(categories))
+	for _, category := range categories {
+		categoryIDs[strings.ToLower(category.Name)] = category.ID
+	}
+
+	var phones, telegramIDs []string
+	for _, row := range rows {
+		if row.RequesterPhone != "" {
+			phones = append(phones, row.RequesterPhone)
+		}
+		if row.RequesterTelegramID != "" {
+			telegramIDs = append(telegramIDs, row.RequesterTelegramID)
+		}
+	}
+	requesters, err := s.repo.Request.FindImportRequesters(ctx, phones, telegramIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Один телефон может принадлежать нескольким пользователям: такой автор неоднозначен
+	byPhone := make(map[string][]int)
+	byTelegram := make(map[string]int)
+	byID := make(map[int]models.RequestImportRequester, len(requesters))
+	for _, requester := range requesters {
+		byID[requester.ID] = requester
+		if requester.Phone != nil && *requester.Phone != "" {
+			byPhone[*requester.Phone] = append(byPhone[*requester.Phone], requester.ID)
+		}
+		if requester.TelegramID != nil {
+			byTelegram[*requester.TelegramID] = requester.ID
+		}
+	}
+
+	now := time.Now()
+	seen := make(map[string]int, len(rows))
+	statuses := make(map[int]models.RequestStatus)
+	items := make([]models.RequestImportItem, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		input := &row.Input
+
+		switch {
+		case row.ExternalID == "":
+			row.Errors = append(row.Errors, "external_id is required")
+		case len(row.ExternalID) > 100:
+			row.Errors = append(row.Errors, "external_id must be at most 100 characters")
+		case seen[row.ExternalID] != 0:
+			row.Errors = append(row.Errors, fmt.Sprintf("external_id duplicates line %d", seen[row.ExternalID]))
+		default:
+			seen[row.ExternalID] = row.Line
+		}
+
+		if n := len([]rune(input.Title)); n < 5 || n > 255 {
+			row.Errors = append(row.Errors, "title must be 5 to 255 characters")
+		}
+		if len([]rune(input.Description)) < 10 {
+			row.Errors = append(row.Errors, "description must be at least 10 characters")
+		}
+		if input.Location == "" {
+			row.Errors = append(row.Errors, "location is required")
+		}
+
+		categoryID, ok := categoryIDs[strings.ToLower(row.Category)]
+		if !ok {
+			row.Errors = append(row.Errors, fmt.Sprintf("unknown category %q", row.Category))
+		}
+
+		switch input.Priority {
+		case "":
+			input.Priority = models.RequestPriorityMedium
+		case models.RequestPriorityLow, models.RequestPriorityMedium, models.RequestPriorityHigh:
+		default:
+			row.Errors = append(row.Errors, "priority must be low, medium or high")
+		}
+
+		if (input.LocationLat == nil) != (input.LocationLon == nil) {
+			row.Errors = append(row.Errors, "location_lat and location_lon must be set together")
+		} else if input.LocationLat != nil &&
+			(*input.LocationLat < -90 || *input.LocationLat > 90 || *input.LocationLon < -180 || *input.LocationLon > 180) {
+			row.Errors = append(row.Errors, "coordinates are out of range")
+		}
+
+		if input.VolunteerSlots == 0 {
+			input.VolunteerSlots = 1
+		}
+		if input.VolunteerSlots < 1 || input.VolunteerSlots > 10 {
+			row.Errors = append(row.Errors, "volunteer_slots must be 1 to 10")
+		}
+
+		if input.DueAt != nil && !input.DueAt.After(now) {
+			row.Errors = append(row.Errors, "due_at must be in the future")
+		}
+
+		requesterID, requesterErr := resolveImportRequester(row, byPhone, byTelegram)
+		if requesterErr != "" {
+			row.Errors = append(row.Errors, requesterErr)
+		} else if byID[requesterID].Suspended {
+			row.Errors = append(row.Errors, "requester account is suspended")
+		}
+
+		if len(row.Errors) > 0 {
+			continue
+		}
+
+		// Начальный статус определяется для автора один раз: история его заявок за время импорта не меняется
+		status, ok := statuses[requesterID]
+		if !ok {
+			status, err = s.initialRequestStatus(ctx, requesterID, byID[requesterID].Role)
+			if err != nil {
+				return nil, err
+			}
+			statuses[requesterID] = status
+		}
+
+		items = append(items, models.RequestImportItem{
+			Line:       row.Line,
+			ExternalID: row.ExternalID,
+			Request: models.HelpRequest{
+				Title:          input.Title,
+				Description:    input.Description,
+				Status:         status,
+				CategoryID:     categoryID,
+				Priority:       input.Priority,
+				Location:       input.Location,
+				LocationLat:    input.LocationLat,
+				LocationLon:    input.LocationLon,
+				RequesterID:    requesterID,
+				VolunteerSlots: input.VolunteerSlots,
+				DueAt:          input.DueAt,
+			},
+		})
+	}
+
+	return items, nil
+}
+
+// resolveImportRequester находит автора заявки по Telegram ID или телефону.
+// Если заданы оба, они должны указывать на одного пользователя. Возвращает текст ошибки строки.
+func resolveImportRequester(row *models.RequestImportRow, byPhone map[string][]int, byTelegram map[string]int) (int, string) {
+	if row.RequesterPhone == "" && row.RequesterTelegramID == "" {
+		return 0, "requester_phone or requester_telegram_id is required"
+	}
+
+	telegramUserID := 0
+	if row.RequesterTelegramID != "" {
+		telegramUserID = byTelegram[row.RequesterTelegramID]
+		if telegramUserID == 0 {
+			return 0, fmt.Sprintf("no user with Telegram ID %q", row.RequesterTelegramID)
+		}
+	}
+
+	if row.RequesterPhone == "" {
+		return telegramUserID, ""
+	}
+
+	phoneUserIDs := byPhone[row.RequesterPhone]
+	if telegramUserID != 0 {
+		for _, id := range phoneUserIDs {
+			if id == telegramUserID {
+				return telegramUserID, ""
+			}
+		}
+		return 0, "requester_phone and requester_telegram_id belong to different users"
+	}
+
+	switch len(phoneUserIDs) {
+	case 0:
+		return 0, fmt.Sprintf("no user with phone %q", row.RequesterPhone)
+	case 1:
+		return phoneUserIDs[0], ""
+	default:
+		return 0, fmt.Sprintf("several users have phone %q, use requester_telegram_id", row.RequesterPhone)
+	}
+}
+
+// importExternalIDs возвращает непустые внешние ID строк импорта
+func importExternalIDs(rows []models.RequestImportRow) []string {
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.ExternalID != "" {
+			ids = append(ids, row.ExternalID)
+		}
+	}
+	return ids
+}
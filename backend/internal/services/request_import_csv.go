@@ -0,0 +1,227 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// maxImportRows - сколько строк с заявками можно импортировать за один раз
+const maxImportRows = 1000
+
+// Колонки CSV импорта заявок
+const (
+	importColumnExternalID          = "external_id"
+	importColumnTitle               = "title"
+	importColumnDescription         = "description"
+	importColumnCategory            = "category"
+	importColumnPriority            = "priority"
+	importColumnLocation            = "location"
+	importColumnLocationLat         = "location_lat"
+	importColumnLocationLon         = "location_lon"
+	importColumnVolunteerSlots      = "volunteer_slots"
+	importColumnDueAt               = "due_at"
+	importColumnRequesterPhone      = "requester_phone"
+	importColumnRequesterTelegramID = "requester_telegram_id"
+)
+
+// importKnownColumns - все колонки, которые понимает импорт
+var importKnownColumns = []string{
+	importColumnExternalID, importColumnTitle, importColumnDescription, importColumnCategory,
+	importColumnPriority, importColumnLocation, importColumnLocationLat, importColumnLocationLon,
+	importColumnVolunteerSlots, importColumnDueAt, importColumnRequesterPhone, importColumnRequesterTelegramID,
+}
+
+// importRequiredColumns - колонки, без которых файл не принимается
+var importRequiredColumns = []string{
+	importColumnExternalID, importColumnTitle, importColumnDescription, importColumnCategory, importColumnLocation,
+}
+
+// parseRequestImport разбирает CSV с заявками. Первая строка - заголовок с именами колонок
+// в любом порядке и регистре; разделитель - запятая или точка с запятой (так сохраняет Excel).
+// Ошибки формата файла возвращаются как ошибка, ошибки значений - в Errors строки.
+func parseRequestImport(data []byte) ([]models.RequestImportRow, error) {
+	// Excel добавляет BOM в начало файла в UTF-8
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectImportDelimiter(data)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: file is empty", models.ErrInvalidRequest)
+		}
+		return nil, fmt.Errorf("%w: invalid CSV: %v", models.ErrInvalidRequest, err)
+	}
+
+	columns, err := importColumnIndexes(header)
+	if err != nil {
+		return nil, err
+	}
+	// Строки могут быть короче заголовка: недостающие значения считаются пустыми
+	reader.FieldsPerRecord = -1
+
+	var rows []models.RequestImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid CSV: %v", models.ErrInvalidRequest, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isBlankImportRecord(record) {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return nil, fmt.Errorf("%w: file has more than %d rows", models.ErrInvalidRequest, maxImportRows)
+		}
+
+		value := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		rows = append(rows, parseImportRecord(line, value))
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: file has no rows", models.ErrInvalidRequest)
+	}
+
+	return rows, nil
+}
+
+// parseImportRecord переводит значения строки в данные заявки
+func parseImportRecord(line int, value func(column string) string) models.RequestImportRow {
+	row := models.RequestImportRow{
+		Line:                line,
+		ExternalID:          value(importColumnExternalID),
+		Category:            value(importColumnCategory),
+		RequesterPhone:      importPhoneDigits(value(importColumnRequesterPhone)),
+		RequesterTelegramID: value(importColumnRequesterTelegramID),
+		Input: models.RequestCreateInput{
+			Title:       value(importColumnTitle),
+			Description: value(importColumnDescription),
+			Location:    value(importColumnLocation),
+			Priority:    models.RequestPriority(strings.ToLower(value(importColumnPriority))),
+		},
+	}
+
+	if raw := value(importColumnLocationLat); raw != "" {
+		lat, err := strconv.ParseFloat(strings.Replace(raw, ",", ".", 1), 64)
+		if err != nil {
+			row.Errors = append(row.Errors, "location_lat must be a number")
+		} else {
+			row.Input.LocationLat = &lat
+		}
+	}
+	if raw := value(importColumnLocationLon); raw != "" {
+		lon, err := strconv.ParseFloat(strings.Replace(raw, ",", ".", 1), 64)
+		if err != nil {
+			row.Errors = append(row.Errors, "location_lon must be a number")
+		} else {
+			row.Input.LocationLon = &lon
+		}
+	}
+
+	if raw := value(importColumnVolunteerSlots); raw != "" {
+		slots, err := strconv.Atoi(raw)
+		if err != nil {
+			row.Errors = append(row.Errors, "volunteer_slots must be an integer")
+		} else {
+			row.Input.VolunteerSlots = slots
+		}
+	}
+
+	if raw := value(importColumnDueAt); raw != "" {
+		dueAt, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			row.Errors = append(row.Errors, "due_at must be in RFC 3339 format, e.g. 2024-05-01T18:00:00+03:00")
+		} else {
+			row.Input.DueAt = &dueAt
+		}
+	}
+
+	return row
+}
+
+// importColumnIndexes сопоставляет колонки заголовка их позициям и проверяет обязательные колонки
+func importColumnIndexes(header []string) (map[string]int, error) {
+	known := make(map[string]bool, len(importKnownColumns))
+	for _, column := range importKnownColumns {
+		known[column] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return nil, fmt.Errorf("%w: unknown column %q", models.ErrInvalidRequest, name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", models.ErrInvalidRequest, name)
+		}
+		columns[name] = i
+	}
+
+	for _, column := range importRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", models.ErrInvalidRequest, column)
+		}
+	}
+	_, hasPhone := columns[importColumnRequesterPhone]
+	_, hasTelegram := columns[importColumnRequesterTelegramID]
+	if !hasPhone && !hasTelegram {
+		return nil, fmt.Errorf("%w: missing column %q or %q", models.ErrInvalidRequest,
+			importColumnRequesterPhone, importColumnRequesterTelegramID)
+	}
+
+	return columns, nil
+}
+
+// detectImportDelimiter выбирает разделитель по заголовку: точка с запятой, если ее больше, чем запятых
+func detectImportDelimiter(data []byte) rune {
+	header := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		header = data[:i]
+	}
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+// isBlankImportRecord проверяет, что в строке нет ни одного значения
+func isBlankImportRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// importPhoneDigits оставляет в телефоне только цифры, как при поиске автора в базе
+func importPhoneDigits(phone string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+}
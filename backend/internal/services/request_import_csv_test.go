@@ -0,0 +1,188 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// importHeader - заголовок CSV с обязательными колонками
+const importHeader = "external_id,title,description,category,location,requester_phone\n"
+
+func TestParseRequestImport(t *testing.T) {
+	dueAt, err := time.Parse(time.RFC3339, "2030-05-01T18:00:00+03:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data string
+		want []models.RequestImportRow
+	}{
+		{
+			name: "required columns",
+			data: importHeader + "a-1,Купить продукты,Нужно купить продукты,Покупки,Москва,+7 (900) 123-45-67\n",
+			want: []models.RequestImportRow{{
+				Line:           2,
+				ExternalID:     "a-1",
+				Category:       "Покупки",
+				RequesterPhone: "79001234567",
+				Input: models.RequestCreateInput{
+					Title:       "Купить продукты",
+					Description: "Нужно купить продукты",
+					Location:    "Москва",
+				},
+			}},
+		},
+		{
+			name: "header in any order and case, BOM and semicolons",
+			data: "\ufeffRequester_Telegram_ID; LOCATION;Category;Description;Title;External_ID;Priority;Location_Lat;Location_Lon;Volunteer_Slots;Due_At\n" +
+				"12345;Москва;Покупки;Нужно купить продукты;Купить продукты;a-1;HIGH;55,75;37,62;2;2030-05-01T18:00:00+03:00\n",
+			want: []models.RequestImportRow{{
+				Line:                2,
+				ExternalID:          "a-1",
+				Category:            "Покупки",
+				RequesterTelegramID: "12345",
+				Input: models.RequestCreateInput{
+					Title:          "Купить продукты",
+					Description:    "Нужно купить продукты",
+					Location:       "Москва",
+					Priority:       models.RequestPriorityHigh,
+					LocationLat:    floatPtr(55.75),
+					LocationLon:    floatPtr(37.62),
+					VolunteerSlots: 2,
+					DueAt:          &dueAt,
+				},
+			}},
+		},
+		{
+			name: "blank rows skipped, short rows padded",
+			data: importHeader + "\n,,,,,\na-1,Купить продукты\n",
+			want: []models.RequestImportRow{{
+				Line:       4,
+				ExternalID: "a-1",
+				Input:      models.RequestCreateInput{Title: "Купить продукты"},
+			}},
+		},
+		{
+			name: "invalid values reported on the row",
+			data: "external_id,title,description,category,location,requester_phone,location_lat,location_lon,volunteer_slots,due_at\n" +
+				"a-1,Купить продукты,Нужно купить продукты,Покупки,Москва,79001234567,north,east,two,tomorrow\n",
+			want: []models.RequestImportRow{{
+				Line:           2,
+				ExternalID:     "a-1",
+				Category:       "Покупки",
+				RequesterPhone: "79001234567",
+				Input: models.RequestCreateInput{
+					Title:       "Купить продукты",
+					Description: "Нужно купить продукты",
+					Location:    "Москва",
+				},
+				Errors: []string{
+					"location_lat must be a number",
+					"location_lon must be a number",
+					"volunteer_slots must be an integer",
+					"due_at must be in RFC 3339 format, e.g. 2024-05-01T18:00:00+03:00",
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRequestImport([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parseRequestImport() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRequestImport() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequestImportFileErrors(t *testing.T) {
+	tooManyRows := importHeader + strings.Repeat("a,b,c,d,e,f\n", maxImportRows+1)
+
+	tests := []struct {
+		name    string
+		data    string
+		wantMsg string
+	}{
+		{name: "empty file", data: "", wantMsg: "file is empty"},
+		{name: "header only", data: importHeader, wantMsg: "file has no rows"},
+		{name: "unknown column", data: importHeader[:len(importHeader)-1] + ",comment\n", wantMsg: `unknown column "comment"`},
+		{name: "duplicate column", data: importHeader[:len(importHeader)-1] + ",Title\n", wantMsg: `duplicate column "title"`},
+		{name: "missing required column", data: "external_id,title,description,category,requester_phone\n", wantMsg: `missing column "location"`},
+		{name: "missing requester columns", data: "external_id,title,description,category,location\n", wantMsg: `missing column "requester_phone" or "requester_telegram_id"`},
+		{name: "broken quotes", data: importHeader + "a-1,\"Купить,b,c,d,e\n", wantMsg: "invalid CSV"},
+		{name: "too many rows", data: tooManyRows, wantMsg: "file has more than 1000 rows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRequestImport([]byte(tt.data))
+			if !errors.Is(err, models.ErrInvalidRequest) {
+				t.Fatalf("parseRequestImport() error = %v, want ErrInvalidRequest", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("parseRequestImport() error = %q, want it to contain %q", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestParseRequestImportRowLimit(t *testing.T) {
+	// Пустые строки не считаются в лимит
+	data := importHeader + strings.Repeat("a,b,c,d,e,f\n\n", maxImportRows)
+
+	rows, err := parseRequestImport([]byte(data))
+	if err != nil {
+		t.Fatalf("parseRequestImport() error = %v", err)
+	}
+	if len(rows) != maxImportRows {
+		t.Errorf("rows = %d, want %d", len(rows), maxImportRows)
+	}
+}
+
+func TestDetectImportDelimiter(t *testing.T) {
+	tests := []struct {
+		data string
+		want rune
+	}{
+		{data: "a,b,c\n1;2;3;4\n", want: ','},
+		{data: "a;b;c\n1,2,3,4\n", want: ';'},
+		{data: "a;b,c", want: ','},
+		{data: "a", want: ','},
+	}
+
+	for _, tt := range tests {
+		if got := detectImportDelimiter([]byte(tt.data)); got != tt.want {
+			t.Errorf("detectImportDelimiter(%q) = %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestImportPhoneDigits(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{phone: "+7 (900) 123-45-67", want: "79001234567"},
+		{phone: "8 900 123 45 67", want: "89001234567"},
+		{phone: "79001234567", want: "79001234567"},
+		{phone: "нет", want: ""},
+		{phone: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := importPhoneDigits(tt.phone); got != tt.want {
+			t.Errorf("importPhoneDigits(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
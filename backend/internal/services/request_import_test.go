@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+func TestResolveImportRequester(t *testing.T) {
+	byPhone := map[string][]int{
+		"79001234567": {1},
+		"79007654321": {2, 3},
+	}
+	byTelegram := map[string]int{
+		"111": 1,
+		"333": 3,
+	}
+
+	tests := []struct {
+		name       string
+		phone      string
+		telegramID string
+		wantID     int
+		wantErr    string
+	}{
+		{name: "no requester", wantErr: "requester_phone or requester_telegram_id is required"},
+		{name: "by phone", phone: "79001234567", wantID: 1},
+		{name: "by Telegram ID", telegramID: "111", wantID: 1},
+		{name: "unknown phone", phone: "70000000000", wantErr: `no user with phone "70000000000"`},
+		{name: "unknown Telegram ID", telegramID: "999", wantErr: `no user with Telegram ID "999"`},
+		{name: "ambiguous phone", phone: "79007654321", wantErr: `several users have phone "79007654321", use requester_telegram_id`},
+		{name: "Telegram ID resolves ambiguous phone", phone: "79007654321", telegramID: "333", wantID: 3},
+		{name: "phone and Telegram ID match", phone: "79001234567", telegramID: "111", wantID: 1},
+		{name: "phone and Telegram ID differ", phone: "79001234567", telegramID: "333", wantErr: "requester_phone and requester_telegram_id belong to different users"},
+		{name: "unknown Telegram ID with known phone", phone: "79001234567", telegramID: "999", wantErr: `no user with Telegram ID "999"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := &models.RequestImportRow{RequesterPhone: tt.phone, RequesterTelegramID: tt.telegramID}
+
+			gotID, gotErr := resolveImportRequester(row, byPhone, byTelegram)
+			if gotID != tt.wantID || gotErr != tt.wantErr {
+				t.Errorf("resolveImportRequester() = (%d, %q), want (%d, %q)", gotID, gotErr, tt.wantID, tt.wantErr)
+			}
+		})
+	}
+}
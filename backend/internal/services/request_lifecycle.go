@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// RequestActor определяет, в какой роли пользователь участвует в переходе заявки
+type RequestActor string
+
+// Роли участников переходов заявки
+const (
+	// RequestActorRequester - автор заявки
+	RequestActorRequester RequestActor = "requester"
+	// RequestActorAssignee - волонтер, за которым закреплена заявка
+	RequestActorAssignee RequestActor = "assignee"
+	// RequestActorVolunteer - любой волонтер, еще не закрепленный за заявкой
+	RequestActorVolunteer RequestActor = "volunteer"
+	// RequestActorAdmin - администратор
+	RequestActorAdmin RequestActor = "admin"
+	// RequestActorSystem - фоновые задачи сервера
+	RequestActorSystem RequestActor = "system"
+)
+
+// requestTransitions описывает допустимые переходы между статусами заявки
+// и роли, которым разрешено их выполнять
+var requestTransitions = map[models.RequestStatus]map[models.RequestStatus][]RequestActor{
+	// Заявки новых и отмеченных авторов сначала проверяет администратор
+	models.RequestStatusPendingReview: {
+		models.RequestStatusNew:              {RequestActorAdmin},
+		models.RequestStatusChangesRequested: {RequestActorAdmin},
+		models.RequestStatusRejected:         {RequestActorAdmin},
+		models.RequestStatusCancelled:        {RequestActorRequester, RequestActorAdmin},
+	},
+	models.RequestStatusChangesRequested: {
+		// Автор исправил заявку и отправил ее на повторную проверку
+		models.RequestStatusPendingReview: {RequestActorRequester},
+		models.RequestStatusCancelled:     {RequestActorRequester, RequestActorAdmin},
+	},
+	// Переходы между "new" и "in_progress" выполняются только вместе с закреплением
+	// и освобождением мест волонтеров (TakeRequest, ReleaseRequest, назначения координатора)
+	models.RequestStatusNew: {
+		models.RequestStatusInProgress: {RequestActorVolunteer, RequestActorAdmin},
+		models.RequestStatusCancelled:  {RequestActorRequester, RequestActorAdmin},
+		// Заявку, не набравшую волонтеров к сроку, закрывает фоновая задача
+		models.RequestStatusExpired: {RequestActorSystem},
+	},
+	models.RequestStatusInProgress: {
+		// Заявка возвращается в поиск волонтеров, когда один из них освобождает место
+		models.RequestStatusNew:       {RequestActorAssignee, RequestActorAdmin},
+		models.RequestStatusCompleted: {RequestActorAssignee, RequestActorRequester, RequestActorAdmin},
+		models.RequestStatusCancelled: {RequestActorRequester, RequestActorAssignee, RequestActorAdmin},
+	},
+}
+
+// CheckRequestTransition проверяет, может ли участник с указанными ролями
+// перевести заявку из статуса from в статус to.
+// Возвращает models.ErrConflict, если такого перехода нет,
+// и models.ErrForbidden, если ни одна из ролей не может его выполнить.
+func CheckRequestTransition(from, to models.RequestStatus, actors []RequestActor) error {
+	allowed, ok := requestTransitions[from][to]
+	if !ok {
+		return fmt.Errorf("%w: transition from %s to %s is not allowed", models.ErrConflict, from, to)
+	}
+
+	for _, actor := range actors {
+		for _, a := range allowed {
+			if actor == a {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: transition from %s to %s is not permitted for this user", models.ErrForbidden, from, to)
+}
+
+// requestActors определяет роли пользователя по отношению к заявке
+func requestActors(request *models.HelpRequest, user *models.User) []RequestActor {
+	var actors []RequestActor
+
+	if request.RequesterID == user.ID {
+		actors = append(actors, RequestActorRequester)
+	}
+
+	if isRequestVolunteer(request, user.ID) {
+		actors = append(actors, RequestActorAssignee)
+	} else if canVolunteer(user.Role) {
+		actors = append(actors, RequestActorVolunteer)
+	}
+
+	if user.Role == models.UserRoleAdmin {
+		actors = append(actors, RequestActorAdmin)
+	}
+
+	return actors
+}
+
+// isRequestVolunteer проверяет, закреплен ли пользователь за заявкой
+func isRequestVolunteer(request *models.HelpRequest, userID int) bool {
+	if request.AssignedTo != nil && *request.AssignedTo == userID {
+		return true
+	}
+
+	for _, id := range request.VolunteerIDs {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// canVolunteer проверяет, может ли пользователь с этой ролью помогать по заявкам
+func canVolunteer(role models.UserRole) bool {
+	return role == models.UserRoleVolunteer || role == models.UserRoleAdmin
+}
+
+// checkAssignableVolunteer проверяет, что заявку можно закрепить за пользователем другим человеком:
+// координатор назначает его или волонтер передает ему заявку. Пользователь должен существовать,
+// помогать по заявкам и не быть заблокирован.
+func (s *RequestService) checkAssignableVolunteer(ctx context.Context, volunteerID int) error {
+	volunteer, err := s.repo.User.GetUserByID(ctx, volunteerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: volunteer not found", models.ErrInvalidRequest)
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !canVolunteer(volunteer.Role) {
+		return fmt.Errorf("%w: user %d is not a volunteer", models.ErrInvalidRequest, volunteerID)
+	}
+
+	suspended, err := s.repo.Request.IsUserSuspended(ctx, volunteerID)
+	if err != nil {
+		return err
+	}
+	if suspended {
+		return fmt.Errorf("%w: volunteer is suspended", models.ErrConflict)
+	}
+
+	return nil
+}
+
+// loadRequestVolunteerIDs заполняет request.VolunteerIDs закрепленными волонтерами
+func (s *RequestService) loadRequestVolunteerIDs(ctx context.Context, request *models.HelpRequest) error {
+	volunteers, err := s.repo.Request.GetRequestVolunteers(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get request volunteers: %w", err)
+	}
+
+	request.VolunteerIDs = make([]int, len(volunteers))
+	for i, v := range volunteers {
+		request.VolunteerIDs[i] = v.ID
+	}
+
+	return nil
+}
+
+// transitionRequest переводит заявку в новый статус от имени пользователя,
+// проверяя переход по машине состояний и записывая его в историю
+func (s *RequestService) transitionRequest(ctx context.Context, userID, requestID int, to models.RequestStatus, reason string) (*models.HelpRequest, error) {
+	request, err := s.checkTransition(ctx, userID, requestID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyTransition(ctx, request, to, &userID, reason)
+}
+
+// checkTransition проверяет по машине состояний, может ли пользователь перевести заявку в статус to,
+// и возвращает заявку для applyTransition
+func (s *RequestService) checkTransition(ctx context.Context, userID, requestID int, to models.RequestStatus) (*models.HelpRequest, error) {
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.loadRequestVolunteerIDs(ctx, request); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.User.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := CheckRequestTransition(request.Status, to, requestActors(request, user)); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// applyTransition сохраняет уже проверенный переход заявки.
+// Волонтер остается закрепленным за заявкой при завершении и отмене.
+func (s *RequestService) applyTransition(ctx context.Context, request *models.HelpRequest, to models.RequestStatus, actorID *int, reason string) (*models.HelpRequest, error) {
+	updated, err := s.repo.Request.ChangeRequestStatus(ctx, &models.RequestStatusChange{
+		RequestID:  request.ID,
+		From:       request.Status,
+		To:         to,
+		AssignedTo: request.AssignedTo,
+		ActorID:    actorID,
+		Reason:     reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, models.ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			// Статус заявки изменился между чтением и записью
+			return nil, fmt.Errorf("%w: request status has changed", models.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to change request status: %w", err)
+	}
+
+	s.publishRequestStatus(ctx, updated, request.Status)
+
+	if to == models.RequestStatusCancelled || to == models.RequestStatusExpired {
+		s.closeRequestTimeEntries(ctx, request.ID)
+	}
+
+	return updated, nil
+}
+
+// GetRequestHistory возвращает историю переходов заявки между статусами
+func (s *RequestService) GetRequestHistory(userID, requestID int) ([]models.RequestStatusHistory, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.requireRequestVisible(ctx, userID, request.RequesterID, request.Status, request.Hidden); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Request.GetRequestStatusHistory(ctx, requestID)
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// maxRequestMessageLength - максимальная длина личного сообщения в символах
+const maxRequestMessageLength = 2000
+
+// GetRequestMessages возвращает страницу личной переписки по заявке: до limit сообщений
+// раньше beforeID (0 - последние), отметки прочтения и число непрочитанных.
+// Переписка доступна автору заявки, ее волонтерам и администраторам.
+func (s *RequestService) GetRequestMessages(userID, requestID, beforeID, limit int) (*models.RequestMessageThread, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := s.getRequestChat(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, hasMore, err := s.repo.Request.GetRequestMessages(ctx, requestID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	reads, err := s.repo.Request.GetRequestMessageReads(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.repo.Request.CountUnreadRequestMessages(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	thread := &models.RequestMessageThread{
+		RequestID:   requestID,
+		Messages:    messages,
+		Reads:       reads,
+		UnreadCount: unread,
+		ReadOnly:    isRequestChatClosed(request.Status),
+	}
+	if hasMore && len(messages) > 0 {
+		thread.NextBeforeID = messages[0].ID
+	}
+
+	return thread, nil
+}
+
+// SendRequestMessage отправляет личное сообщение по заявке. Остальные участники получают
+// уведомление, если до этого сообщения прочитали всю переписку, - чтобы каждое
+// сообщение подряд не порождало отдельное уведомление.
+func (s *RequestService) SendRequestMessage(userID, requestID int, input models.RequestMessageInput) (*models.RequestMessage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	text := strings.TrimSpace(input.Text)
+	if length := len([]rune(text)); length == 0 || length > maxRequestMessageLength {
+		return nil, fmt.Errorf("%w: message text must be from 1 to %d characters", models.ErrInvalidRequest, maxRequestMessageLength)
+	}
+
+	request, err := s.getRequestChat(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if isRequestChatClosed(request.Status) {
+		return nil, fmt.Errorf("%w: chat is read-only for %s request", models.ErrConflict, request.Status)
+	}
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Переписку видят все участники, поэтому писать нельзя, если кто-то из них внес пользователя в черный список
+	recipients := requestChatParticipants(request, userID)
+	blocked, err := s.repo.Request.IsBlockedByAny(ctx, recipients, userID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, fmt.Errorf("%w: you have been blocked by a participant of this chat", models.ErrForbidden)
+	}
+
+	// Участники, у которых нет непрочитанных сообщений, до сохранения нового
+	notify := make([]int, 0, len(recipients))
+	for _, recipientID := range recipients {
+		unread, err := s.repo.Request.CountUnreadRequestMessages(ctx, requestID, recipientID)
+		if err != nil {
+			return nil, err
+		}
+		if unread == 0 {
+			notify = append(notify, recipientID)
+		}
+	}
+
+	message, err := s.repo.Request.AddRequestMessage(ctx, &models.RequestMessage{
+		RequestID: requestID,
+		SenderID:  userID,
+		Text:      text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sender, err := s.repo.User.GetUserByID(ctx, userID); err == nil {
+		message.Sender = &models.UserShort{
+			ID:        sender.ID,
+			Username:  sender.Username,
+			FirstName: sender.FirstName,
+			LastName:  sender.LastName,
+			PhotoURL:  sender.PhotoURL,
+		}
+	}
+
+	for _, recipientID := range notify {
+		s.notify(ctx, recipientID, models.NotificationTypeRequestMessage, requestID,
+			"Новое сообщение",
+			fmt.Sprintf("Новое сообщение в переписке по заявке «%s».", request.Title))
+	}
+
+	return message, nil
+}
+
+// MarkRequestMessagesRead отмечает переписку прочитанной до сообщения messageID (0 - до последнего)
+func (s *RequestService) MarkRequestMessagesRead(userID, requestID, messageID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.getRequestChat(ctx, userID, requestID); err != nil {
+		return err
+	}
+
+	return s.repo.Request.MarkRequestMessagesRead(ctx, requestID, userID, messageID)
+}
+
+// GetUnreadMessageCounts возвращает заявки пользователя с непрочитанными личными сообщениями
+func (s *RequestService) GetUnreadMessageCounts(userID int) ([]models.RequestUnreadMessages, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.repo.Request.GetUnreadMessageCounts(ctx, userID)
+}
+
+// getRequestChat возвращает заявку с закрепленными волонтерами, если пользователь
+// может читать ее переписку: автор, волонтер заявки или администратор
+func (s *RequestService) getRequestChat(ctx context.Context, userID, requestID int) (*models.HelpRequest, error) {
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.loadRequestVolunteerIDs(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if request.RequesterID != userID && !isRequestVolunteer(request, userID) {
+		if err := s.requireAdmin(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return request, nil
+}
+
+// requestChatParticipants возвращает автора и волонтеров заявки, кроме пользователя exceptID
+func requestChatParticipants(request *models.HelpRequest, exceptID int) []int {
+	seen := map[int]bool{exceptID: true}
+	participants := make([]int, 0, len(request.VolunteerIDs)+1)
+
+	add := func(id int) {
+		if !seen[id] {
+			seen[id] = true
+			participants = append(participants, id)
+		}
+	}
+
+	add(request.RequesterID)
+	if request.AssignedTo != nil {
+		add(*request.AssignedTo)
+	}
+	for _, id := range request.VolunteerIDs {
+		add(id)
+	}
+
+	return participants
+}
+
+// isRequestChatClosed проверяет, доступна ли переписка по заявке только для чтения
+func isRequestChatClosed(status models.RequestStatus) bool {
+	switch status {
+	case models.RequestStatusCompleted, models.RequestStatusCancelled, models.RequestStatusExpired:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// SetModerationPolicy задает правила автоматического одобрения заявок
+func (s *RequestService) SetModerationPolicy(policy models.ModerationPolicy) {
+	s.moderationPolicy = policy
+}
+
+// requiresModeration определяет, нужно ли проверять заявки автора перед публикацией
+func requiresModeration(policy models.ModerationPolicy, role models.UserRole, history *models.RequesterHistory) bool {
+	if !policy.Enabled || role == models.UserRoleAdmin {
+		return false
+	}
+	if history.RequiresReview {
+		return true
+	}
+
+	return history.CompletedRequests < policy.TrustedCompletedRequests ||
+		history.RejectedRequests > policy.MaxRejectedRequests
+}
+
+// initialRequestStatus возвращает статус новой заявки автора: сразу в поиск волонтеров или на проверку
+func (s *RequestService) initialRequestStatus(ctx context.Context, userID int, role models.UserRole) (models.RequestStatus, error) {
+	history, err := s.repo.Request.GetRequesterHistory(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get requester history: %w", err)
+	}
+
+	if requiresModeration(s.moderationPolicy, role, history) {
+		return models.RequestStatusPendingReview, nil
+	}
+
+	return models.RequestStatusNew, nil
+}
+
+// GetModerationQueue возвращает заявки, ожидающие проверки, самые давние первыми
+func (s *RequestService) GetModerationQueue(limit, offset int) ([]models.RequestFullInfo, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.repo.Request.GetModerationQueue(ctx, limit, offset)
+}
+
+// ApproveRequest одобряет заявку на проверке: она становится видна волонтерам
+func (s *RequestService) ApproveRequest(adminID, requestID int) (models.RequestFullInfo, error) {
+	return s.moderateRequest(adminID, requestID, models.RequestStatusNew, "",
+		"Заявка опубликована", "Заявка «%s» прошла проверку и видна волонтерам.")
+}
+
+// RejectRequest отклоняет заявку на проверке с обязательной причиной
+func (s *RequestService) RejectRequest(adminID, requestID int, input models.ModerationDecisionInput) (models.RequestFullInfo, error) {
+	if input.Reason == "" {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: reason is required", models.ErrInvalidRequest)
+	}
+
+	return s.moderateRequest(adminID, requestID, models.RequestStatusRejected, input.Reason,
+		"Заявка отклонена", "Заявка «%s» не прошла проверку.")
+}
+
+// RequestChanges возвращает заявку на проверке автору на доработку с обязательной причиной.
+// После изменения автором заявка снова попадает в очередь проверки.
+func (s *RequestService) RequestChanges(adminID, requestID int, input models.ModerationDecisionInput) (models.RequestFullInfo, error) {
+	if input.Reason == "" {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: reason is required", models.ErrInvalidRequest)
+	}
+
+	return s.moderateRequest(adminID, requestID, models.RequestStatusChangesRequested, input.Reason,
+		"Заявку нужно исправить", "Заявку «%s» нужно исправить перед публикацией.")
+}
+
+// moderateRequest переводит заявку на проверке в новый статус и уведомляет автора
+func (s *RequestService) moderateRequest(adminID, requestID int, to models.RequestStatus, reason, title, messageFormat string) (models.RequestFullInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Переход проверяется машиной состояний: решение по заявке на проверке принимает администратор
+	request, err := s.transitionRequest(ctx, adminID, requestID, to, reason)
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	message := fmt.Sprintf(messageFormat, request.Title)
+	if reason != "" {
+		message += fmt.Sprintf(" Причина: %s.", reason)
+	}
+	s.notify(ctx, request.RequesterID, models.NotificationTypeRequestModerated, requestID, title, message)
+
+	return s.getRequestFullInfo(ctx, requestID)
+}
+
+// SetUserRequiresReview отмечает пользователя, все заявки которого проходят проверку, или снимает отметку
+func (s *RequestService) SetUserRequiresReview(userID int, input models.UserReviewFlagInput) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.repo.Request.SetUserRequiresReview(ctx, userID, input.RequiresReview); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
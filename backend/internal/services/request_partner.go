@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// checkPartnerStaff проверяет, что пользователь может создать заявку от имени организации:
+// организация проверена, а пользователь - ее сотрудник
+func (s *RequestService) checkPartnerStaff(ctx context.Context, partnerID, userID int) error {
+	partner, err := s.repo.Partner.GetPartnerByID(ctx, partnerID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: partner not found", models.ErrInvalidRequest)
+		}
+		return fmt.Errorf("failed to get partner: %w", err)
+	}
+	if !partner.IsVerified {
+		return fmt.Errorf("%w: partner is not verified", models.ErrForbidden)
+	}
+
+	if _, err := s.repo.Partner.GetMemberRole(ctx, partnerID, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: you are not a member of the partner", models.ErrForbidden)
+		}
+		return fmt.Errorf("failed to get partner membership: %w", err)
+	}
+
+	return nil
+}
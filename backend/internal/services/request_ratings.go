@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// defaultRatingPriorMean и defaultRatingPriorWeight - к оценкам пользователя добавляется
+// defaultRatingPriorWeight оценок, равных defaultRatingPriorMean.
+// Одна оценка 5 не поднимает новичка на вершину рейтинга.
+const (
+	defaultRatingPriorMean   = 4
+	defaultRatingPriorWeight = 5
+)
+
+// SetRatingPrior задает априорную оценку mean и ее вес weight в рейтинге пользователя
+func (s *RequestService) SetRatingPrior(mean, weight float64) {
+	s.ratingPriorMean = mean
+	s.ratingPriorWeight = weight
+}
+
+// RateRequest оценивает участника выполненной заявки: автор оценивает волонтера,
+// волонтер - автора. Каждый участник оценивает другого один раз.
+func (s *RequestService) RateRequest(userID, requestID int, input models.RequestRatingInput) (*models.RequestRating, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if input.Rating < 1 || input.Rating > 5 {
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", models.ErrInvalidRequest)
+	}
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if request.Status != models.RequestStatusCompleted {
+		return nil, fmt.Errorf("%w: only completed requests can be rated", models.ErrConflict)
+	}
+
+	if err := s.loadRequestVolunteerIDs(ctx, request); err != nil {
+		return nil, err
+	}
+
+	ratedID, err := ratedParticipant(request, userID, input.RatedID)
+	if err != nil {
+		return nil, err
+	}
+
+	rating, err := s.repo.Request.AddRating(ctx, &models.RequestRating{
+		RequestID: requestID,
+		RaterID:   userID,
+		RatedID:   ratedID,
+		Rating:    input.Rating,
+		Comment:   input.Comment,
+	}, s.ratingPriorMean, s.ratingPriorWeight)
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, fmt.Errorf("%w: you have already rated this participant", models.ErrConflict)
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: user not found", models.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	// Опыт за положительную оценку получает только волонтер
+	if userID == request.RequesterID && input.Rating >= 4 {
+		expAmount := 15
+		if input.Rating == 5 {
+			expAmount = 25
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.gameService.AddExperience(ctx, ratedID, expAmount, "positive_rating"); err != nil {
+				s.logger.WithError(err).WithField("user_id", ratedID).Error("Failed to add experience for positive rating")
+			}
+		}()
+	}
+
+	return rating, nil
+}
+
+// ratedParticipant определяет, кого оценивает пользователь в заявке.
+// Автор оценивает одного из волонтеров (единственного - по умолчанию), волонтер - автора.
+func ratedParticipant(request *models.HelpRequest, userID int, ratedID *int) (int, error) {
+	if userID == request.RequesterID {
+		if ratedID == nil {
+			if len(request.VolunteerIDs) != 1 {
+				return 0, fmt.Errorf("%w: ratedId is required", models.ErrInvalidRequest)
+			}
+			return request.VolunteerIDs[0], nil
+		}
+		if *ratedID == userID || !isRequestVolunteer(request, *ratedID) {
+			return 0, fmt.Errorf("%w: rated user is not a volunteer of the request", models.ErrInvalidRequest)
+		}
+		return *ratedID, nil
+	}
+
+	if isRequestVolunteer(request, userID) {
+		if ratedID != nil && *ratedID != request.RequesterID {
+			return 0, fmt.Errorf("%w: volunteers can rate only the requester", models.ErrInvalidRequest)
+		}
+		return request.RequesterID, nil
+	}
+
+	return 0, models.ErrForbidden
+}
+
+// GetUserReviews возвращает рейтинг пользователя и страницу полученных им отзывов
+func (s *RequestService) GetUserReviews(userID, limit, offset int) (*models.UserReviews, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := s.repo.User.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return s.repo.Request.GetUserReviews(ctx, userID, limit, offset)
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// recommendationCandidatesLimit - сколько открытых заявок оценивается для одной подборки
+const recommendationCandidatesLimit = 200
+
+// SetRecommendationWeights задает веса сигналов в оценке рекомендованных заявок
+func (s *RequestService) SetRecommendationWeights(weights models.RecommendationWeights) {
+	s.recommendationWeights = weights
+}
+
+// GetRecommendedRequests подбирает волонтеру открытые заявки, лучшие первыми.
+// Каждая заявка объясняется главными причинами оценки.
+func (s *RequestService) GetRecommendedRequests(userID, limit int) ([]models.RecommendedRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", models.ErrInvalidRequest)
+	}
+
+	user, err := s.repo.User.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.UserRoleVolunteer && user.Role != models.UserRoleAdmin {
+		return nil, models.ErrForbidden
+	}
+
+	profile, err := s.repo.Request.GetVolunteerProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	candidates, err := s.repo.Request.GetRecommendationCandidates(ctx, userID, recommendationCandidatesLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	recommended := make([]models.RecommendedRequest, 0, len(candidates))
+	for i := range candidates {
+		recommended = append(recommended, scoreRecommendation(&candidates[i], profile, s.recommendationWeights, now))
+	}
+
+	// При равной оценке первыми идут более давние заявки, в порядке выборки
+	sort.SliceStable(recommended, func(i, j int) bool {
+		return recommended[i].Score > recommended[j].Score
+	})
+	if len(recommended) > limit {
+		recommended = recommended[:limit]
+	}
+
+	return recommended, nil
+}
+
+// SetHomeLocation сохраняет домашнюю точку пользователя для рекомендаций заявок поблизости.
+// Пустые координаты удаляют домашнюю точку.
+func (s *RequestService) SetHomeLocation(userID int, input models.HomeLocationInput) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if (input.Lat == nil) != (input.Lon == nil) {
+		return fmt.Errorf("%w: lat and lon must be set together", models.ErrInvalidRequest)
+	}
+	if input.Lat != nil && (*input.Lat < -90 || *input.Lat > 90 || *input.Lon < -180 || *input.Lon > 180) {
+		return fmt.Errorf("%w: coordinates are out of range", models.ErrInvalidRequest)
+	}
+
+	if err := s.repo.Request.SetHomeLocation(ctx, userID, input.Lat, input.Lon); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
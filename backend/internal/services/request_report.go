@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// GetRequestReport возвращает операционный отчет по заявкам, созданным в периоде:
+// медиану и 90-й перцентиль времени до назначения и до выполнения, доли отмененных
+// и истекших заявок в выбранном разрезе. Доступно только администраторам.
+func (s *RequestService) GetRequestReport(adminID int, filter models.RequestReportFilter) (*models.RequestReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if !filter.GroupBy.IsValid() {
+		return nil, fmt.Errorf("%w: unknown report grouping %q", models.ErrInvalidRequest, filter.GroupBy)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: period start must be before its end", models.ErrInvalidRequest)
+	}
+
+	groups, summary, err := s.repo.Request.GetRequestReport(ctx, filter)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get request report")
+		return nil, err
+	}
+
+	for i := range groups {
+		setRequestReportRates(&groups[i])
+	}
+	setRequestReportRates(summary)
+
+	return &models.RequestReport{
+		From:    filter.From,
+		To:      filter.To,
+		GroupBy: filter.GroupBy,
+		Groups:  groups,
+		Summary: *summary,
+	}, nil
+}
+
+// setRequestReportRates заполняет доли отмененных и истекших заявок
+func setRequestReportRates(row *models.RequestReportRow) {
+	if row.Total == 0 {
+		return
+	}
+	row.CancellationRate = math.Round(float64(row.Cancelled)/float64(row.Total)*1000) / 1000
+	row.ExpiryRate = math.Round(float64(row.Expired)/float64(row.Total)*1000) / 1000
+}
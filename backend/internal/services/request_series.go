@@ -0,0 +1,529 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// dateLayout - формат дат начала и окончания серии
+const dateLayout = "2006-01-02"
+
+// timeOfDayLayout - формат времени повторения серии
+const timeOfDayLayout = "15:04"
+
+// nextSeriesOccurrence возвращает первое повторение серии строго после after.
+// Повторения отсчитываются от даты начала серии, поэтому для biweekly
+// чередование недель не сбивается при изменении серии.
+// Возвращает nil, если после after повторений в пределах серии нет.
+func nextSeriesOccurrence(series *models.RequestSeries, after time.Time) (*time.Time, error) {
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidRequest, series.Timezone)
+	}
+
+	clock, err := time.Parse(timeOfDayLayout, series.TimeOfDay)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid time of day %q", models.ErrInvalidRequest, series.TimeOfDay)
+	}
+
+	// Повторение в заданный день в часовом поясе серии
+	at := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+	}
+
+	start := time.Date(series.StartsOn.Year(), series.StartsOn.Month(), series.StartsOn.Day(), 0, 0, 0, 0, loc)
+
+	var day time.Time
+	var step func(time.Time, int) time.Time
+
+	switch series.Frequency {
+	case models.RecurrenceWeekly, models.RecurrenceBiweekly:
+		if series.DayOfWeek == nil {
+			return nil, fmt.Errorf("%w: day of week is required", models.ErrInvalidRequest)
+		}
+		days := 7
+		if series.Frequency == models.RecurrenceBiweekly {
+			days = 14
+		}
+		day = start.AddDate(0, 0, (*series.DayOfWeek-int(start.Weekday())+7)%7)
+		step = func(d time.Time, n int) time.Time { return d.AddDate(0, 0, n*days) }
+
+		// Пропускаем целые периоды до after, чтобы не перебирать их по одному
+		if periods := int(after.Sub(at(day)).Hours()/24) / days; periods > 0 {
+			day = step(day, periods)
+		}
+	case models.RecurrenceMonthly:
+		if series.DayOfMonth == nil {
+			return nil, fmt.Errorf("%w: day of month is required", models.ErrInvalidRequest)
+		}
+		day = time.Date(start.Year(), start.Month(), *series.DayOfMonth, 0, 0, 0, 0, loc)
+		if day.Before(start) {
+			day = day.AddDate(0, 1, 0)
+		}
+		step = func(d time.Time, n int) time.Time { return d.AddDate(0, n, 0) }
+
+		afterLocal := after.In(loc)
+		if months := (afterLocal.Year()-day.Year())*12 + int(afterLocal.Month()-day.Month()) - 1; months > 0 {
+			day = step(day, months)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown frequency %q", models.ErrInvalidRequest, series.Frequency)
+	}
+
+	for !at(day).After(after) {
+		day = step(day, 1)
+	}
+
+	if series.EndsOn != nil {
+		end := time.Date(series.EndsOn.Year(), series.EndsOn.Month(), series.EndsOn.Day(), 0, 0, 0, 0, loc)
+		if day.After(end) {
+			return nil, nil
+		}
+	}
+
+	next := at(day)
+	return &next, nil
+}
+
+// applyRecurrenceRule проверяет правило повторения и переносит его в серию
+func applyRecurrenceRule(series *models.RequestSeries, rule *models.RecurrenceRuleInput) error {
+	series.Frequency = rule.Frequency
+	series.DayOfWeek = nil
+	series.DayOfMonth = nil
+
+	switch rule.Frequency {
+	case models.RecurrenceWeekly, models.RecurrenceBiweekly:
+		if rule.DayOfWeek == nil || *rule.DayOfWeek < 0 || *rule.DayOfWeek > 6 {
+			return fmt.Errorf("%w: dayOfWeek must be between 0 (Sunday) and 6", models.ErrInvalidRequest)
+		}
+		series.DayOfWeek = rule.DayOfWeek
+	case models.RecurrenceMonthly:
+		// Дни после 28-го есть не в каждом месяце
+		if rule.DayOfMonth == nil || *rule.DayOfMonth < 1 || *rule.DayOfMonth > 28 {
+			return fmt.Errorf("%w: dayOfMonth must be between 1 and 28", models.ErrInvalidRequest)
+		}
+		series.DayOfMonth = rule.DayOfMonth
+	default:
+		return fmt.Errorf("%w: frequency must be weekly, biweekly or monthly", models.ErrInvalidRequest)
+	}
+
+	if _, err := time.Parse(timeOfDayLayout, rule.Time); err != nil {
+		return fmt.Errorf("%w: time must be in HH:MM format", models.ErrInvalidRequest)
+	}
+	series.TimeOfDay = rule.Time
+
+	series.Timezone = rule.Timezone
+	if series.Timezone == "" {
+		series.Timezone = models.DefaultSeriesTimezone
+	}
+	loc, err := time.LoadLocation(series.Timezone)
+	if err != nil {
+		return fmt.Errorf("%w: unknown timezone %q", models.ErrInvalidRequest, series.Timezone)
+	}
+
+	if rule.StartsOn != "" {
+		startsOn, err := time.Parse(dateLayout, rule.StartsOn)
+		if err != nil {
+			return fmt.Errorf("%w: startsOn must be in YYYY-MM-DD format", models.ErrInvalidRequest)
+		}
+		series.StartsOn = startsOn
+	} else if series.StartsOn.IsZero() {
+		now := time.Now().In(loc)
+		series.StartsOn = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	series.EndsOn = nil
+	if rule.EndsOn != "" {
+		endsOn, err := time.Parse(dateLayout, rule.EndsOn)
+		if err != nil {
+			return fmt.Errorf("%w: endsOn must be in YYYY-MM-DD format", models.ErrInvalidRequest)
+		}
+		if endsOn.Before(series.StartsOn) {
+			return fmt.Errorf("%w: endsOn is before startsOn", models.ErrInvalidRequest)
+		}
+		series.EndsOn = &endsOn
+	}
+
+	return nil
+}
+
+// createRequestSeries создает серию повторяющихся заявок и сразу создает ее первое повторение.
+// Остальные повторения создает планировщик.
+func (s *RequestService) createRequestSeries(ctx context.Context, userID int, input models.RequestCreateInput) (models.RequestFullInfo, error) {
+	series := &models.RequestSeries{
+		RequesterID:    userID,
+		Title:          input.Title,
+		Description:    input.Description,
+		CategoryID:     input.CategoryID,
+		Priority:       input.Priority,
+		Location:       input.Location,
+		LocationLat:    input.LocationLat,
+		LocationLon:    input.LocationLon,
+		VolunteerSlots: input.VolunteerSlots,
+		Status:         models.RequestSeriesStatusActive,
+	}
+	if series.Priority == "" {
+		series.Priority = models.RequestPriorityMedium
+	}
+	if series.VolunteerSlots == 0 {
+		series.VolunteerSlots = 1
+	}
+
+	if err := applyRecurrenceRule(series, input.Recurrence); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	first, err := nextSeriesOccurrence(series, time.Now())
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+	if first == nil {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: series has no upcoming occurrences", models.ErrInvalidRequest)
+	}
+	series.NextOccurrenceAt = first
+
+	created, err := s.repo.Request.CreateRequestSeries(ctx, series)
+	if err != nil {
+		return models.RequestFullInfo{}, fmt.Errorf("failed to create request series: %w", err)
+	}
+
+	following, err := nextSeriesOccurrence(created, *first)
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	occurrence, err := s.repo.Request.CreateSeriesOccurrence(ctx, created, following)
+	if err != nil {
+		return models.RequestFullInfo{}, fmt.Errorf("failed to create first series occurrence: %w", err)
+	}
+	s.publishRequestCreated(ctx, occurrence.ID)
+
+	return s.getRequestFullInfo(ctx, occurrence.ID)
+}
+
+// GenerateSeriesOccurrences создает повторения активных серий, запланированные
+// не позднее чем через lookahead. Вызывается планировщиком фоновых задач.
+func (s *RequestService) GenerateSeriesOccurrences(ctx context.Context, lookahead time.Duration) error {
+	horizon := time.Now().Add(lookahead)
+
+	due, err := s.repo.Request.GetDueRequestSeries(ctx, horizon)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+	for i := range due {
+		series := &due[i]
+
+		for series.NextOccurrenceAt != nil && !series.NextOccurrenceAt.After(horizon) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			next, err := nextSeriesOccurrence(series, *series.NextOccurrenceAt)
+			if err != nil {
+				s.logger.WithError(err).WithField("series_id", series.ID).Error("Invalid request series schedule")
+				break
+			}
+
+			occurrence, err := s.repo.Request.CreateSeriesOccurrence(ctx, series, next)
+			if err != nil {
+				// Серию изменили или ее повторение уже создал другой экземпляр сервера
+				if !errors.Is(err, repository.ErrConflict) {
+					s.logger.WithError(err).WithField("series_id", series.ID).Error("Failed to create series occurrence")
+				}
+				break
+			}
+			s.publishRequestCreated(ctx, occurrence.ID)
+
+			created++
+			series.NextOccurrenceAt = next
+		}
+	}
+
+	if created > 0 {
+		s.logger.WithField("count", created).Info("Created request series occurrences")
+	}
+
+	return nil
+}
+
+// GetRequestSeries возвращает серию повторяющихся заявок
+func (s *RequestService) GetRequestSeries(seriesID int) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	series, err := s.repo.Request.GetRequestSeriesByID(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetUserRequestSeries возвращает серии пользователя: созданные им и те, где он волонтер по умолчанию
+func (s *RequestService) GetUserRequestSeries(userID int) ([]models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.repo.Request.GetUserRequestSeries(ctx, userID)
+}
+
+// UpdateRequestSeries изменяет серию. Уже созданные повторения не меняются.
+func (s *RequestService) UpdateRequestSeries(userID, seriesID int, input models.RequestSeriesUpdateInput) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := s.getManagedRequestSeries(ctx, userID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.Status == models.RequestSeriesStatusEnded {
+		return nil, fmt.Errorf("%w: series has ended", models.ErrConflict)
+	}
+
+	if input.Title != nil {
+		series.Title = *input.Title
+	}
+	if input.Description != nil {
+		series.Description = *input.Description
+	}
+	if input.CategoryID != nil {
+		series.CategoryID = *input.CategoryID
+	}
+	if input.Priority != nil {
+		series.Priority = *input.Priority
+	}
+	if input.Location != nil {
+		series.Location = *input.Location
+	}
+	if input.LocationLat != nil {
+		series.LocationLat = input.LocationLat
+	}
+	if input.LocationLon != nil {
+		series.LocationLon = input.LocationLon
+	}
+	if input.VolunteerSlots != nil {
+		if *input.VolunteerSlots < 1 || *input.VolunteerSlots > 10 {
+			return nil, fmt.Errorf("%w: volunteerSlots must be between 1 and 10", models.ErrInvalidRequest)
+		}
+		series.VolunteerSlots = *input.VolunteerSlots
+	}
+
+	if input.Recurrence != nil {
+		if err := applyRecurrenceRule(series, input.Recurrence); err != nil {
+			return nil, err
+		}
+
+		if series.Status == models.RequestSeriesStatusActive {
+			if err := s.rescheduleRequestSeries(ctx, series); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.saveRequestSeries(ctx, series)
+}
+
+// PauseRequestSeries приостанавливает создание повторений серии
+func (s *RequestService) PauseRequestSeries(userID, seriesID int) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := s.getManagedRequestSeries(ctx, userID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.Status != models.RequestSeriesStatusActive {
+		return nil, fmt.Errorf("%w: only an active series can be paused", models.ErrConflict)
+	}
+
+	series.Status = models.RequestSeriesStatusPaused
+	series.NextOccurrenceAt = nil
+
+	return s.saveRequestSeries(ctx, series)
+}
+
+// ResumeRequestSeries возобновляет серию. Повторения, пропущенные за время паузы, не создаются.
+func (s *RequestService) ResumeRequestSeries(userID, seriesID int) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := s.getManagedRequestSeries(ctx, userID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.Status != models.RequestSeriesStatusPaused {
+		return nil, fmt.Errorf("%w: only a paused series can be resumed", models.ErrConflict)
+	}
+
+	series.Status = models.RequestSeriesStatusActive
+	if err := s.rescheduleRequestSeries(ctx, series); err != nil {
+		return nil, err
+	}
+
+	return s.saveRequestSeries(ctx, series)
+}
+
+// EndRequestSeries завершает серию. Уже созданные повторения остаются и отменяются отдельно.
+func (s *RequestService) EndRequestSeries(userID, seriesID int) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := s.getManagedRequestSeries(ctx, userID, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.Status == models.RequestSeriesStatusEnded {
+		return nil, fmt.Errorf("%w: series has already ended", models.ErrConflict)
+	}
+
+	series.Status = models.RequestSeriesStatusEnded
+	series.NextOccurrenceAt = nil
+
+	return s.saveRequestSeries(ctx, series)
+}
+
+// JoinRequestSeries делает волонтера помощником по умолчанию для всех будущих повторений серии
+func (s *RequestService) JoinRequestSeries(userID, seriesID int) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	user, err := s.repo.User.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.Role != models.UserRoleVolunteer && user.Role != models.UserRoleAdmin {
+		return nil, models.ErrForbidden
+	}
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	series, err := s.GetRequestSeries(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked, err := s.repo.Request.IsBlockedByAny(ctx, []int{series.RequesterID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, fmt.Errorf("%w: the series author has blocked you", models.ErrForbidden)
+	}
+
+	if series.Status == models.RequestSeriesStatusEnded {
+		return nil, fmt.Errorf("%w: series has ended", models.ErrConflict)
+	}
+	if series.DefaultVolunteerID != nil && *series.DefaultVolunteerID != userID {
+		return nil, fmt.Errorf("%w: series already has a default volunteer", models.ErrConflict)
+	}
+
+	series.DefaultVolunteerID = &userID
+
+	return s.saveRequestSeries(ctx, series)
+}
+
+// LeaveRequestSeries снимает волонтера по умолчанию с серии.
+// Это может сделать сам волонтер, автор серии или администратор.
+func (s *RequestService) LeaveRequestSeries(userID, seriesID int) (*models.RequestSeries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := s.GetRequestSeries(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.DefaultVolunteerID == nil {
+		return nil, fmt.Errorf("%w: series has no default volunteer", models.ErrConflict)
+	}
+
+	if *series.DefaultVolunteerID != userID {
+		if series, err = s.getManagedRequestSeries(ctx, userID, seriesID); err != nil {
+			return nil, err
+		}
+	}
+
+	series.DefaultVolunteerID = nil
+
+	return s.saveRequestSeries(ctx, series)
+}
+
+// getManagedRequestSeries получает серию, которой пользователь может управлять:
+// автор серии или администратор
+func (s *RequestService) getManagedRequestSeries(ctx context.Context, userID, seriesID int) (*models.RequestSeries, error) {
+	series, err := s.repo.Request.GetRequestSeriesByID(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if series.RequesterID != userID {
+		user, err := s.repo.User.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Role != models.UserRoleAdmin {
+			return nil, models.ErrForbidden
+		}
+	}
+
+	return series, nil
+}
+
+// rescheduleRequestSeries пересчитывает следующее повторение серии от текущего момента,
+// не повторяя уже созданные повторения
+func (s *RequestService) rescheduleRequestSeries(ctx context.Context, series *models.RequestSeries) error {
+	after := time.Now()
+
+	last, err := s.repo.Request.GetLastSeriesOccurrenceTime(ctx, series.ID)
+	if err != nil {
+		return err
+	}
+	if last != nil && last.After(after) {
+		after = *last
+	}
+
+	next, err := nextSeriesOccurrence(series, after)
+	if err != nil {
+		return err
+	}
+
+	series.NextOccurrenceAt = next
+	if next == nil {
+		series.Status = models.RequestSeriesStatusEnded
+	}
+
+	return nil
+}
+
+// saveRequestSeries сохраняет серию и преобразует ошибки репозитория
+func (s *RequestService) saveRequestSeries(ctx context.Context, series *models.RequestSeries) (*models.RequestSeries, error) {
+	updated, err := s.repo.Request.UpdateRequestSeries(ctx, series)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update request series: %w", err)
+	}
+
+	return updated, nil
+}
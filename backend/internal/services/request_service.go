@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/events"
 	"moshosp/backend/internal/repository"
 	"moshosp/backend/internal/repository/requestrepo"
 )
@@ -18,55 +21,99 @@ type RequestService struct {
 	repo        *repository.Repository
 	logger      *logrus.Logger
 	gameService *GameService
+	// recommendationWeights - веса сигналов в оценке рекомендованных заявок
+	recommendationWeights models.RecommendationWeights
+	// moderationPolicy - правила автоматического одобрения новых заявок
+	moderationPolicy models.ModerationPolicy
+	// ratingPriorMean и ratingPriorWeight - априорная оценка и ее вес в рейтинге пользователя
+	ratingPriorMean   float64
+	ratingPriorWeight float64
+	// reportHideThreshold - сколько открытых жалоб скрывает заявку или комментарий; 0 - не скрывать
+	reportHideThreshold int
+	// maxVolunteerActiveRequests - сколько незавершенных заявок координатор может назначить одному волонтеру
+	maxVolunteerActiveRequests int
+	// events - поток событий для клиентов; nil, если поток не подключен
+	events *events.Hub
 }
 
 // NewRequestService создает новый экземпляр сервиса запросов
 func NewRequestService(repo *repository.Repository, gameService *GameService, logger *logrus.Logger) *RequestService {
 	return &RequestService{
-		repo:        repo,
-		logger:      logger,
-		gameService: gameService,
+		repo:                       repo,
+		logger:                     logger,
+		gameService:                gameService,
+		recommendationWeights:      models.DefaultRecommendationWeights,
+		moderationPolicy:           models.DefaultModerationPolicy,
+		ratingPriorMean:            defaultRatingPriorMean,
+		ratingPriorWeight:          defaultRatingPriorWeight,
+		reportHideThreshold:        defaultReportHideThreshold,
+		maxVolunteerActiveRequests: defaultMaxVolunteerActiveRequests,
 	}
 }
 
-// GetRequests возвращает список запросов с фильтрацией и пагинацией
-func (s *RequestService) GetRequests(status, category string, page, limit int) ([]models.RequestFullInfo, int, error) {
+// GetRequests возвращает страницу ленты заявок с фильтрацией
+func (s *RequestService) GetRequests(filter models.RequestFilter) (*models.RequestList, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return s.repo.Request.GetRequests(ctx, status, category, page, limit)
+	if filter.Limit <= 0 {
+		return nil, fmt.Errorf("%w: limit must be positive", models.ErrInvalidRequest)
+	}
+	if filter.Sort != models.RequestSortDefault && filter.Sort != models.RequestSortDueSoon {
+		return nil, fmt.Errorf("%w: unknown sort %q", models.ErrInvalidRequest, filter.Sort)
+	}
+
+	list, err := s.repo.Request.GetRequests(ctx, filter)
+	if err != nil {
+		if errors.Is(err, requestrepo.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidRequest, err)
+		}
+		return nil, err
+	}
+
+	return list, nil
 }
 
-// GetRequestByID возвращает информацию о запросе по ID
-func (s *RequestService) GetRequestByID(id int) (models.RequestFullInfo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetNearbyRequests возвращает заявки рядом с указанной точкой, ближайшие первыми
+func (s *RequestService) GetNearbyRequests(filter models.NearbyRequestFilter) ([]models.RequestFullInfo, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	request, err := s.repo.Request.GetRequestByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
-			return models.RequestFullInfo{}, models.ErrNotFound
-		}
-		return models.RequestFullInfo{}, err
+	if filter.RadiusKm <= 0 {
+		return nil, 0, fmt.Errorf("%w: radius must be positive", models.ErrInvalidRequest)
 	}
 
-	return request, nil
+	return s.repo.Request.GetNearbyRequests(ctx, filter)
 }
 
-// GetRequestComments возвращает комментарии для запроса
-func (s *RequestService) GetRequestComments(requestID int) ([]models.RequestComment, error) {
+// SearchRequests выполняет полнотекстовый поиск заявок
+func (s *RequestService) SearchRequests(filter models.RequestSearchFilter) ([]models.RequestSearchResult, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if strings.TrimSpace(filter.Query) == "" {
+		return nil, 0, fmt.Errorf("%w: search query is empty", models.ErrInvalidRequest)
+	}
+
+	return s.repo.Request.SearchRequests(ctx, filter)
+}
+
+// GetRequestByID возвращает информацию о запросе по ID.
+// userID - просматривающий пользователь, 0 для анонимного просмотра.
+func (s *RequestService) GetRequestByID(userID, id int) (models.RequestFullInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	comments, err := s.repo.Request.GetRequestComments(ctx, requestID)
+	request, err := s.getRequestFullInfo(ctx, id)
 	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
-			return nil, models.ErrNotFound
-		}
-		return nil, err
+		return models.RequestFullInfo{}, err
+	}
+
+	if err := s.requireRequestVisible(ctx, userID, request.RequesterID, request.Status, request.Hidden); err != nil {
+		return models.RequestFullInfo{}, err
 	}
 
-	return comments, nil
+	return request, nil
 }
 
 // CreateRequest создает новый запрос на помощь
@@ -80,23 +127,69 @@ func (s *RequestService) CreateRequest(userID int, input models.RequestCreateInp
 		return models.RequestFullInfo{}, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	// Заявки новых и отмеченных авторов публикуются только после проверки
+	status, err := s.initialRequestStatus(ctx, user.ID, user.Role)
+	if err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
+	// Заявку от имени организации создает ее сотрудник; проверенный партнер публикует заявки без проверки
+	if input.PartnerID != nil {
+		if input.Recurrence != nil {
+			return models.RequestFullInfo{}, fmt.Errorf("%w: recurring requests cannot be created on behalf of a partner", models.ErrInvalidRequest)
+		}
+		if err := s.checkPartnerStaff(ctx, *input.PartnerID, userID); err != nil {
+			return models.RequestFullInfo{}, err
+		}
+		status = models.RequestStatusNew
+	}
+
+	// Повторяющаяся заявка создается как серия со своим первым повторением.
+	// Повторения серии публикуются без проверки, поэтому серии доступны только проверенным авторам.
+	if input.Recurrence != nil {
+		if status == models.RequestStatusPendingReview {
+			return models.RequestFullInfo{}, fmt.Errorf("%w: recurring requests are available after your requests pass review", models.ErrForbidden)
+		}
+		return s.createRequestSeries(ctx, user.ID, input)
+	}
+
+	if input.DueAt != nil && !input.DueAt.After(time.Now()) {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: dueAt must be in the future", models.ErrInvalidRequest)
+	}
+
+	// По умолчанию заявке нужен один волонтер
+	volunteerSlots := input.VolunteerSlots
+	if volunteerSlots == 0 {
+		volunteerSlots = 1
+	}
+
 	// Создание запроса
 	request := models.HelpRequest{
-		Title:       input.Title,
-		Description: input.Description,
-		Location:    input.Location,
-		CategoryID:  input.CategoryID,
-		Priority:    models.RequestPriority(input.Priority),
-		Status:      models.RequestStatusNew,
-		AuthorID:    userID,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		Title:          input.Title,
+		Description:    input.Description,
+		Location:       input.Location,
+		CategoryID:     input.CategoryID,
+		Priority:       models.RequestPriority(input.Priority),
+		Status:         status,
+		VolunteerSlots: volunteerSlots,
+		DueAt:          input.DueAt,
+		PartnerID:      input.PartnerID,
+		AuthorID:       userID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	createdRequest, err := s.repo.Request.CreateRequest(ctx, request)
 	if err != nil {
 		return models.RequestFullInfo{}, fmt.Errorf("failed to create request: %w", err)
 	}
+	if status == models.RequestStatusNew {
+		s.publishRequestCreated(ctx, createdRequest.ID)
+	}
 
 	// Добавление опыта за создание запроса
 	go func() {
@@ -121,6 +214,10 @@ func (s *RequestService) UpdateRequest(userID, requestID int, input models.Reque
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return models.RequestFullInfo{}, err
+	}
+
 	// Получение текущего запроса
 	existingRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
 	if err != nil {
@@ -130,10 +227,12 @@ func (s *RequestService) UpdateRequest(userID, requestID int, input models.Reque
 		return models.RequestFullInfo{}, err
 	}
 
-	// Проверка прав на редактирование
-	if existingRequest.Author.ID != userID {
-		// Проверка на администратора может быть добавлена здесь
-		return models.RequestFullInfo{}, models.ErrForbidden
+	// Проверка прав на редактирование: автор или администратор.
+	// Назначать волонтера через assignedTo может только администратор.
+	if existingRequest.RequesterID != userID || input.AssignedTo != nil {
+		if err := s.requireAdmin(ctx, userID); err != nil {
+			return models.RequestFullInfo{}, err
+		}
 	}
 
 	// Обновление полей запроса
@@ -156,13 +255,69 @@ func (s *RequestService) UpdateRequest(userID, requestID int, input models.Reque
 	if input.Priority != nil {
 		updateData.Priority = models.RequestPriority(*input.Priority)
 	}
+	if input.VolunteerSlots != nil {
+		// Число мест меняется только у заявки, которая еще набирает волонтеров,
+		// и не может стать меньше или равным числу уже закрепленных
+		volunteers, err := s.repo.Request.GetRequestVolunteers(ctx, requestID)
+		if err != nil {
+			return models.RequestFullInfo{}, fmt.Errorf("failed to get request volunteers: %w", err)
+		}
+		if existingRequest.Status != models.RequestStatusNew || *input.VolunteerSlots <= len(volunteers) {
+			return models.RequestFullInfo{}, fmt.Errorf("%w: volunteer slots cannot be changed", models.ErrConflict)
+		}
+		updateData.VolunteerSlots = *input.VolunteerSlots
+	}
+	if input.DueAt != nil {
+		// Срок имеет смысл, пока заявка ищет волонтеров
+		if existingRequest.Status != models.RequestStatusNew {
+			return models.RequestFullInfo{}, fmt.Errorf("%w: due date cannot be changed", models.ErrConflict)
+		}
+		if !input.DueAt.After(time.Now()) {
+			return models.RequestFullInfo{}, fmt.Errorf("%w: dueAt must be in the future", models.ErrInvalidRequest)
+		}
+		updateData.DueAt = input.DueAt
+	}
 	updateData.UpdatedAt = time.Now()
 
+	// Смена статуса через общее обновление проходит ту же машину состояний,
+	// что и отдельные действия над заявкой. "new" и "in_progress" меняются только
+	// вместе с местами волонтеров, поэтому через общее обновление не выставляются.
+	// Переход проверяется до записи полей, а сохраняется после нее.
+	var transition *models.HelpRequest
+	if input.Status != nil && *input.Status != existingRequest.Status {
+		if *input.Status == models.RequestStatusNew || *input.Status == models.RequestStatusInProgress {
+			return models.RequestFullInfo{}, fmt.Errorf("%w: status %s is changed by taking or releasing the request", models.ErrInvalidRequest, *input.Status)
+		}
+		transition, err = s.checkTransition(ctx, userID, requestID, *input.Status)
+		if err != nil {
+			return models.RequestFullInfo{}, err
+		}
+	}
+
 	err = s.repo.Request.UpdateRequest(ctx, updateData)
 	if err != nil {
 		return models.RequestFullInfo{}, fmt.Errorf("failed to update request: %w", err)
 	}
 
+	if transition != nil {
+		if _, err := s.applyTransition(ctx, transition, *input.Status, &userID, ""); err != nil {
+			return models.RequestFullInfo{}, err
+		}
+	}
+
+	// Исправленная по замечаниям заявка возвращается в очередь проверки
+	if existingRequest.Status == models.RequestStatusChangesRequested && existingRequest.RequesterID == userID && input.Status == nil {
+		if _, err := s.transitionRequest(ctx, userID, requestID, models.RequestStatusPendingReview, ""); err != nil {
+			return models.RequestFullInfo{}, err
+		}
+	}
+
+	if input.AssignedTo != nil {
+		if _, err := s.assignRequest(ctx, userID, requestID, *input.AssignedTo); err != nil {
+			return models.RequestFullInfo{}, err
+		}
+	}
+
 	// Получаем обновленную информацию о запросе
 	updatedRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
 	if err != nil {
@@ -201,92 +356,59 @@ func (s *RequestService) DeleteRequest(userID, requestID int) error {
 	return nil
 }
 
-// AddComment добавляет комментарий к запросу
-func (s *RequestService) AddComment(userID, requestID int, input models.RequestCommentInput) (models.RequestComment, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// TakeRequest закрепляет за волонтером свободное место в запросе.
+// Запрос переходит в работу, когда заняты все места.
+func (s *RequestService) TakeRequest(userID, requestID int) (models.RequestFullInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Проверка существования запроса
-	_, err := s.repo.Request.GetRequestByID(ctx, requestID)
-	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
-			return models.RequestComment{}, models.ErrNotFound
-		}
-		return models.RequestComment{}, err
-	}
-
-	// Проверка существования пользователя
+	// Проверка, что пользователь является волонтером
 	user, err := s.repo.User.GetUserByID(ctx, userID)
 	if err != nil {
-		return models.RequestComment{}, fmt.Errorf("failed to get user: %w", err)
-	}
-
-	// Создание комментария
-	comment := models.RequestComment{
-		RequestID: requestID,
-		UserID:    userID,
-		Text:      input.Text,
-		CreatedAt: time.Now(),
+		return models.RequestFullInfo{}, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	createdComment, err := s.repo.Request.AddComment(ctx, comment)
-	if err != nil {
-		return models.RequestComment{}, fmt.Errorf("failed to add comment: %w", err)
+	if user.Role != models.UserRoleVolunteer && user.Role != models.UserRoleAdmin {
+		return models.RequestFullInfo{}, models.ErrForbidden
 	}
 
-	// Добавляем информацию о пользователе в комментарий
-	createdComment.User = models.UserShort{
-		ID:        user.ID,
-		Name:      user.Name,
-		AvatarURL: user.AvatarURL,
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return models.RequestFullInfo{}, err
 	}
 
-	// Добавление опыта за комментарий
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		if err := s.gameService.AddExperience(ctx, userID, 5, "add_comment"); err != nil {
-			s.logger.WithError(err).Error("Failed to add experience for comment")
-		}
-	}()
-
-	return createdComment, nil
-}
-
-// TakeRequest позволяет волонтеру взять запрос на выполнение
-func (s *RequestService) TakeRequest(userID, requestID int) (models.RequestFullInfo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	// Получение текущего запроса
-	existingRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
 	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
+		if errors.Is(err, repository.ErrNotFound) {
 			return models.RequestFullInfo{}, models.ErrNotFound
 		}
 		return models.RequestFullInfo{}, err
 	}
 
-	// Проверка статуса запроса
-	if existingRequest.Status != models.RequestStatusNew {
-		return models.RequestFullInfo{}, models.ErrConflict
+	if err := s.requireRequestVisible(ctx, userID, request.RequesterID, request.Status, request.Hidden); err != nil {
+		return models.RequestFullInfo{}, err
 	}
 
-	// Проверка, что пользователь является волонтером
-	user, err := s.repo.User.GetUserByID(ctx, userID)
+	blocked, err := s.repo.Request.IsBlockedByAny(ctx, []int{request.RequesterID}, userID)
 	if err != nil {
-		return models.RequestFullInfo{}, fmt.Errorf("failed to get user: %w", err)
+		return models.RequestFullInfo{}, err
 	}
-
-	if user.Role != models.UserRoleVolunteer && user.Role != models.UserRoleAdmin {
-		return models.RequestFullInfo{}, models.ErrForbidden
+	if blocked {
+		return models.RequestFullInfo{}, fmt.Errorf("%w: the request author has blocked you", models.ErrForbidden)
 	}
 
-	// Обновление запроса
-	err = s.repo.Request.UpdateRequestStatus(ctx, requestID, models.RequestStatusInProgress, userID)
+	// Места занимаются под блокировкой строки запроса, поэтому волонтеров
+	// не может оказаться больше, чем мест в запросе
+	claimed, err := s.repo.Request.ClaimRequest(ctx, requestID, userID)
 	if err != nil {
-		return models.RequestFullInfo{}, fmt.Errorf("failed to update request status: %w", err)
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return models.RequestFullInfo{}, models.ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return models.RequestFullInfo{}, models.ErrConflict
+		}
+		return models.RequestFullInfo{}, fmt.Errorf("failed to claim request: %w", err)
 	}
+	s.publishRequestStatus(ctx, claimed, models.RequestStatusNew)
 
 	// Добавление опыта за взятие запроса
 	go func() {
@@ -297,13 +419,7 @@ func (s *RequestService) TakeRequest(userID, requestID int) (models.RequestFullI
 		}
 	}()
 
-	// Получаем обновленную информацию о запросе
-	updatedRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
-	if err != nil {
-		return models.RequestFullInfo{}, fmt.Errorf("failed to get updated request: %w", err)
-	}
-
-	return updatedRequest, nil
+	return s.getRequestFullInfo(ctx, requestID)
 }
 
 // CompleteRequest отмечает запрос как выполненный
@@ -311,179 +427,119 @@ func (s *RequestService) CompleteRequest(userID, requestID int) (models.RequestF
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Получение текущего запроса
-	existingRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	// Переход проверяется машиной состояний: завершить заявку в работе могут
+	// закрепленный волонтер, автор или администратор
+	completedRequest, err := s.transitionRequest(ctx, userID, requestID, models.RequestStatusCompleted, "")
 	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
-			return models.RequestFullInfo{}, models.ErrNotFound
-		}
 		return models.RequestFullInfo{}, err
 	}
 
-	// Проверка, что пользователь является исполнителем запроса или автором
-	if existingRequest.Volunteer.ID != userID && existingRequest.Author.ID != userID {
-		// Проверка на администратора может быть добавлена здесь
-		return models.RequestFullInfo{}, models.ErrForbidden
+	volunteers, err := s.repo.Request.GetRequestVolunteers(ctx, requestID)
+	if err != nil {
+		return models.RequestFullInfo{}, fmt.Errorf("failed to get request volunteers: %w", err)
 	}
 
-	// Проверка статуса запроса
-	if existingRequest.Status != models.RequestStatusInProgress {
-		return models.RequestFullInfo{}, fmt.Errorf("request must be in progress to complete it")
+	// Заявка уже завершена, поэтому ошибка учета часов не отменяет завершение
+	if err := s.rollUpVolunteerHours(ctx, completedRequest); err != nil {
+		s.logger.WithError(err).WithField("request_id", requestID).Error("Failed to roll up volunteer hours")
 	}
 
-	// Обновление запроса
-	err = s.repo.Request.UpdateRequestStatus(ctx, requestID, models.RequestStatusCompleted, 0)
-	if err != nil {
-		return models.RequestFullInfo{}, fmt.Errorf("failed to update request status: %w", err)
-	}
-
-	// Добавление опыта за выполнение запроса
-	if existingRequest.Volunteer.ID > 0 {
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			// Опыт в зависимости от приоритета запроса
-			expAmount := 30
-			switch existingRequest.Priority {
-			case models.RequestPriorityHigh:
-				expAmount = 50
-			case models.RequestPriorityMedium:
-				expAmount = 30
-			case models.RequestPriorityLow:
-				expAmount = 20
-			}
+	// Добавление опыта за выполнение запроса каждому волонтеру
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
 
-			if err := s.gameService.AddExperience(ctx, existingRequest.Volunteer.ID, expAmount, "complete_request"); err != nil {
-				s.logger.WithError(err).Error("Failed to add experience for completing request")
-			}
-		}()
-	}
+		// Опыт в зависимости от приоритета запроса
+		expAmount := 30
+		switch completedRequest.Priority {
+		case models.RequestPriorityHigh:
+			expAmount = 50
+		case models.RequestPriorityMedium:
+			expAmount = 30
+		case models.RequestPriorityLow:
+			expAmount = 20
+		}
 
-	// Получаем обновленную информацию о запросе
-	updatedRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
-	if err != nil {
-		return models.RequestFullInfo{}, fmt.Errorf("failed to get updated request: %w", err)
-	}
+		for _, volunteer := range volunteers {
+			if err := s.gameService.AddExperience(ctx, volunteer.ID, expAmount, "complete_request"); err != nil {
+				s.logger.WithError(err).WithField("user_id", volunteer.ID).Error("Failed to add experience for completing request")
+			}
+		}
+	}()
 
-	return updatedRequest, nil
+	return s.getRequestFullInfo(ctx, requestID)
 }
 
 // CancelRequest отменяет запрос
-func (s *RequestService) CancelRequest(userID, requestID int) (models.RequestFullInfo, error) {
+func (s *RequestService) CancelRequest(userID, requestID int, reason string) (models.RequestFullInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// Получение текущего запроса
-	existingRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
-	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
-			return models.RequestFullInfo{}, models.ErrNotFound
-		}
+	// Переход проверяется машиной состояний: новую заявку может отменить автор,
+	// заявку в работе - также закрепленный волонтер; администратор может всегда
+	if _, err := s.transitionRequest(ctx, userID, requestID, models.RequestStatusCancelled, reason); err != nil {
 		return models.RequestFullInfo{}, err
 	}
 
-	// Проверка, что пользователь является автором запроса или исполнителем
-	if existingRequest.Author.ID != userID && existingRequest.Volunteer.ID != userID {
-		// Проверка на администратора может быть добавлена здесь
-		return models.RequestFullInfo{}, models.ErrForbidden
-	}
-
-	// Проверка статуса запроса
-	if existingRequest.Status == models.RequestStatusCompleted || existingRequest.Status == models.RequestStatusCancelled {
-		return models.RequestFullInfo{}, fmt.Errorf("request already completed or cancelled")
-	}
-
-	// Обновление запроса
-	err = s.repo.Request.UpdateRequestStatus(ctx, requestID, models.RequestStatusCancelled, 0)
-	if err != nil {
-		return models.RequestFullInfo{}, fmt.Errorf("failed to update request status: %w", err)
-	}
+	return s.getRequestFullInfo(ctx, requestID)
+}
 
-	// Получаем обновленную информацию о запросе
-	updatedRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
+// getRequestFullInfo получает полную информацию о запросе после его изменения
+func (s *RequestService) getRequestFullInfo(ctx context.Context, requestID int) (models.RequestFullInfo, error) {
+	fullInfo, err := s.repo.Request.GetRequestFullInfo(ctx, requestID)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.RequestFullInfo{}, models.ErrNotFound
+		}
 		return models.RequestFullInfo{}, fmt.Errorf("failed to get updated request: %w", err)
 	}
 
-	return updatedRequest, nil
+	return *fullInfo, nil
 }
 
-// RateRequest оценивает выполненный запрос
-func (s *RequestService) RateRequest(userID, requestID int, input models.RequestRatingInput) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// requireRequestVisible скрывает заявки вне ленты (на проверке, на доработке, отклоненные)
+// и скрытые по жалобам от всех, кроме автора и администраторов: остальным такая заявка
+// отвечает models.ErrNotFound. userID 0 означает анонимного пользователя.
+func (s *RequestService) requireRequestVisible(ctx context.Context, userID, requesterID int, status models.RequestStatus, hidden bool) error {
+	if (status.IsFeedVisible() && !hidden) || (userID != 0 && userID == requesterID) {
+		return nil
+	}
+	if userID == 0 {
+		return models.ErrNotFound
+	}
 
-	// Получение текущего запроса
-	existingRequest, err := s.repo.Request.GetRequestByID(ctx, requestID)
-	if err != nil {
-		if errors.Is(err, requestrepo.ErrRequestNotFound) {
+	if err := s.requireAdmin(ctx, userID); err != nil {
+		if errors.Is(err, models.ErrForbidden) {
 			return models.ErrNotFound
 		}
 		return err
 	}
 
-	// Проверка, что пользователь является автором запроса
-	if existingRequest.Author.ID != userID {
-		return models.ErrForbidden
-	}
-
-	// Проверка статуса запроса
-	if existingRequest.Status != models.RequestStatusCompleted {
-		return fmt.Errorf("only completed requests can be rated")
-	}
-
-	// Проверка, что запрос еще не оценен
-	hasRating, err := s.repo.Request.HasRating(ctx, requestID)
-	if err != nil {
-		return fmt.Errorf("failed to check rating: %w", err)
-	}
-	if hasRating {
-		return models.ErrConflict
-	}
+	return nil
+}
 
-	// Создание оценки
-	rating := models.RequestRating{
-		RequestID: requestID,
+// notify отправляет пользователю уведомление о заявке.
+// Ошибка уведомления не отменяет уже выполненное действие и только записывается в журнал.
+func (s *RequestService) notify(ctx context.Context, userID int, notificationType models.NotificationType, requestID int, title, message string) {
+	notification, err := s.repo.Game.CreateNotification(ctx, &models.Notification{
+		ID:        uuid.New().String(),
 		UserID:    userID,
-		Rating:    input.Rating,
-		Feedback:  input.Feedback,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		RequestID: &requestID,
 		CreatedAt: time.Now(),
-	}
-
-	// Добавление оценки
-	err = s.repo.Request.AddRating(ctx, rating)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to add rating: %w", err)
-	}
-
-	// Добавление опыта волонтеру за положительную оценку
-	if existingRequest.Volunteer.ID > 0 && input.Rating >= 4 {
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-
-			// Опыт в зависимости от оценки
-			expAmount := 0
-			switch input.Rating {
-			case 5:
-				expAmount = 25
-			case 4:
-				expAmount = 15
-			}
-
-			if expAmount > 0 {
-				if err := s.gameService.AddExperience(ctx, existingRequest.Volunteer.ID, expAmount, "positive_rating"); err != nil {
-					s.logger.WithError(err).Error("Failed to add experience for positive rating")
-				}
-			}
-		}()
+		s.logger.WithError(err).WithField("user_id", userID).WithField("request_id", requestID).
+			Error("Failed to create request notification")
+		return
 	}
-
-	return nil
+	s.publishNotification(notification)
 }
 
-// GetRequestStats возвращает статистику по запросам
+// GetRequestStats возвращает статистику по запросам. Статистика читается из дневных сводок
+// (см. RefreshStatsRollups) и отстает от текущих данных на интервал их пересчета.
 func (s *RequestService) GetRequestStats() (models.RequestStats, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -499,20 +555,14 @@ func (s *RequestService) GetRequestCategories() ([]models.RequestCategory, error
 	return s.repo.Request.GetRequestCategories(ctx)
 }
 
-// GetUserRequests возвращает список запросов, созданных пользователем
-func (s *RequestService) GetUserRequests(userID int, status string, page, limit int) ([]models.RequestFullInfo, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	offset := (page - 1) * limit
-	return s.repo.Request.GetUserRequests(ctx, userID, status, limit, offset)
+// GetUserRequests возвращает страницу заявок, созданных пользователем
+func (s *RequestService) GetUserRequests(userID int, filter models.RequestFilter) (*models.RequestList, error) {
+	filter.RequesterID = userID
+	return s.GetRequests(filter)
 }
 
-// GetVolunteerRequests возвращает список запросов, взятых пользователем как волонтер
-func (s *RequestService) GetVolunteerRequests(userID int, status string, page, limit int) ([]models.RequestFullInfo, int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	offset := (page - 1) * limit
-	return s.repo.Request.GetVolunteerRequests(ctx, userID, status, limit, offset)
+// GetVolunteerRequests возвращает страницу заявок, взятых пользователем как волонтер
+func (s *RequestService) GetVolunteerRequests(userID int, filter models.RequestFilter) (*models.RequestList, error) {
+	filter.VolunteerID = userID
+	return s.GetRequests(filter)
 }
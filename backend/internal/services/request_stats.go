@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+)
+
+// maxStatsPoints ограничивает длину временного ряда статистики
+const maxStatsPoints = 400
+
+// RefreshStatsRollups пересчитывает дневные сводки статистики за последние lookbackDays дней.
+// Вызывается планировщиком фоновых задач.
+func (s *RequestService) RefreshStatsRollups(ctx context.Context, lookbackDays int) error {
+	start := time.Now()
+	if err := s.repo.Request.RefreshStatsRollups(ctx, lookbackDays); err != nil {
+		return err
+	}
+
+	s.logger.WithField("duration", time.Since(start)).Debug("Refreshed stats rollups")
+	return nil
+}
+
+// GetStatsTimeseries возвращает временной ряд показателя для главной страницы
+func (s *RequestService) GetStatsTimeseries(filter models.StatsTimeseriesFilter) (*models.StatsTimeseries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if !filter.Metric.IsValid() {
+		return nil, fmt.Errorf("%w: unknown metric %q", models.ErrInvalidRequest, filter.Metric)
+	}
+	if !filter.Interval.IsValid() {
+		return nil, fmt.Errorf("%w: unknown interval %q", models.ErrInvalidRequest, filter.Interval)
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: period start must be before its end", models.ErrInvalidRequest)
+	}
+	if statsPointsCount(filter) > maxStatsPoints {
+		return nil, fmt.Errorf("%w: period is too long for interval %q", models.ErrInvalidRequest, filter.Interval)
+	}
+
+	points, err := s.repo.Request.GetStatsTimeseries(ctx, filter)
+	if err != nil {
+		s.logger.WithError(err).WithField("metric", filter.Metric).Error("Failed to get stats timeseries")
+		return nil, err
+	}
+
+	return &models.StatsTimeseries{
+		Metric:   filter.Metric,
+		Interval: filter.Interval,
+		Points:   points,
+	}, nil
+}
+
+// statsPointsCount оценивает число шагов ряда сверху
+func statsPointsCount(filter models.StatsTimeseriesFilter) int {
+	days := int(filter.To.Sub(filter.From).Hours()/24) + 1
+	switch filter.Interval {
+	case models.StatsIntervalWeek:
+		return days/7 + 1
+	case models.StatsIntervalMonth:
+		return days/28 + 1
+	default:
+		return days
+	}
+}
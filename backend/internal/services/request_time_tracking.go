@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// maxTimeEntryMinutes - сколько минут засчитывается за одну отметку времени.
+// Защищает статистику от забытых отметок об окончании работы.
+const maxTimeEntryMinutes = 12 * 60
+
+// CheckIn отмечает начало работы волонтера над заявкой
+func (s *RequestService) CheckIn(userID, requestID int) (*models.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	request, err := s.getAssignedRequest(ctx, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Status != models.RequestStatusNew && request.Status != models.RequestStatusInProgress {
+		return nil, fmt.Errorf("%w: request is %s", models.ErrConflict, request.Status)
+	}
+
+	entry, err := s.repo.Request.CheckIn(ctx, requestID, userID, time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, fmt.Errorf("%w: already checked in", models.ErrConflict)
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// CheckOut отмечает окончание работы волонтера над заявкой
+func (s *RequestService) CheckOut(userID, requestID int) (*models.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.requireActiveUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getAssignedRequest(ctx, userID, requestID); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.repo.Request.CheckOut(ctx, requestID, userID, time.Now(), maxTimeEntryMinutes)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: not checked in", models.ErrConflict)
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetRequestTimeEntries возвращает отметки времени по заявке.
+// Их видят автор заявки, ее волонтеры и администраторы.
+func (s *RequestService) GetRequestTimeEntries(userID, requestID int) ([]models.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.loadRequestVolunteerIDs(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if request.RequesterID != userID && !isRequestVolunteer(request, userID) {
+		user, err := s.repo.User.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if user.Role != models.UserRoleAdmin {
+			return nil, models.ErrForbidden
+		}
+	}
+
+	return s.repo.Request.GetRequestTimeEntries(ctx, requestID)
+}
+
+// CorrectTimeEntry исправляет отметку времени. Доступно только администраторам.
+// Если заявка уже завершена, часы волонтера пересчитываются.
+func (s *RequestService) CorrectTimeEntry(adminID, entryID int, input models.TimeEntryCorrectionInput) (*models.TimeEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if input.Reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", models.ErrInvalidRequest)
+	}
+	if input.CheckedInAt.IsZero() || input.CheckedOutAt.IsZero() || input.CheckedOutAt.Before(input.CheckedInAt) {
+		return nil, fmt.Errorf("%w: checkedOutAt must not be before checkedInAt", models.ErrInvalidRequest)
+	}
+	if input.CheckedOutAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: checkedOutAt is in the future", models.ErrInvalidRequest)
+	}
+
+	entry, err := s.repo.Request.GetTimeEntryByID(ctx, entryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	entry.CheckedInAt = input.CheckedInAt
+	entry.CheckedOutAt = &input.CheckedOutAt
+	entry.CorrectedBy = &adminID
+	entry.CorrectionReason = &input.Reason
+
+	corrected, err := s.repo.Request.CorrectTimeEntry(ctx, entry, maxTimeEntryMinutes)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, models.ErrNotFound
+		case errors.Is(err, repository.ErrConflict):
+			return nil, fmt.Errorf("%w: volunteer has another open time entry", models.ErrConflict)
+		}
+		return nil, err
+	}
+
+	request, err := s.repo.Request.GetRequestByID(ctx, corrected.RequestID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if request != nil && request.Status == models.RequestStatusCompleted {
+		if err := s.repo.Request.RecalculateVolunteerHours(ctx, corrected.VolunteerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return corrected, nil
+}
+
+// rollUpVolunteerHours закрывает незакрытые отметки завершенной заявки
+// и пересчитывает часы всех волонтеров, отмечавших по ней время,
+// в том числе уже освободивших свое место
+func (s *RequestService) rollUpVolunteerHours(ctx context.Context, request *models.HelpRequest) error {
+	completedAt := time.Now()
+	if request.CompletedAt != nil {
+		completedAt = *request.CompletedAt
+	}
+
+	if err := s.repo.Request.CloseOpenTimeEntries(ctx, request.ID, completedAt, maxTimeEntryMinutes); err != nil {
+		return err
+	}
+
+	entries, err := s.repo.Request.GetRequestTimeEntries(ctx, request.ID)
+	if err != nil {
+		return err
+	}
+
+	recalculated := make(map[int]bool)
+	for _, entry := range entries {
+		if recalculated[entry.VolunteerID] {
+			continue
+		}
+		if err := s.repo.Request.RecalculateVolunteerHours(ctx, entry.VolunteerID); err != nil {
+			return err
+		}
+		recalculated[entry.VolunteerID] = true
+	}
+
+	return nil
+}
+
+// closeVolunteerTimeEntry закрывает незакрытую отметку волонтера, освободившего место в заявке.
+// Иначе он не сможет отметить окончание работы, а при завершении заявки ему засчитается лишнее время.
+// Ошибка не отменяет освобождение места и только записывается в журнал.
+func (s *RequestService) closeVolunteerTimeEntry(ctx context.Context, requestID, volunteerID int) {
+	_, err := s.repo.Request.CheckOut(ctx, requestID, volunteerID, time.Now(), maxTimeEntryMinutes)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		s.logger.WithError(err).
+			WithField("request_id", requestID).
+			WithField("volunteer_id", volunteerID).
+			Error("Failed to close volunteer time entry")
+	}
+}
+
+// closeRequestTimeEntries закрывает все незакрытые отметки отмененной или истекшей заявки.
+// Ошибка не отменяет смену статуса и только записывается в журнал.
+func (s *RequestService) closeRequestTimeEntries(ctx context.Context, requestID int) {
+	if err := s.repo.Request.CloseOpenTimeEntries(ctx, requestID, time.Now(), maxTimeEntryMinutes); err != nil {
+		s.logger.WithError(err).WithField("request_id", requestID).Error("Failed to close request time entries")
+	}
+}
+
+// getAssignedRequest получает заявку, за которой закреплен волонтер.
+// Возвращает models.ErrForbidden, если пользователь не закреплен за заявкой.
+func (s *RequestService) getAssignedRequest(ctx context.Context, userID, requestID int) (*models.HelpRequest, error) {
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := s.loadRequestVolunteerIDs(ctx, request); err != nil {
+		return nil, err
+	}
+
+	if !isRequestVolunteer(request, userID) {
+		return nil, fmt.Errorf("%w: user is not assigned to this request", models.ErrForbidden)
+	}
+
+	return request, nil
+}
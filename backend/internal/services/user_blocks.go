@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"moshosp/backend/internal/domain/models"
+	"moshosp/backend/internal/repository"
+)
+
+// maxUserBlockReasonLength - максимальная длина причины блокировки
+const maxUserBlockReasonLength = 500
+
+// BlockUser добавляет пользователя в черный список. Администраторов заблокировать нельзя,
+// чтобы координаторы всегда могли связаться с участниками заявок.
+func (s *RequestService) BlockUser(userID, targetID int, input models.UserBlockInput) (*models.UserBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if targetID == userID {
+		return nil, fmt.Errorf("%w: you cannot block yourself", models.ErrInvalidRequest)
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if len([]rune(reason)) > maxUserBlockReasonLength {
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", models.ErrInvalidRequest, maxUserBlockReasonLength)
+	}
+
+	target, err := s.repo.User.GetUserByID(ctx, targetID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if target.Role == models.UserRoleAdmin {
+		return nil, fmt.Errorf("%w: administrators cannot be blocked", models.ErrInvalidRequest)
+	}
+
+	return s.repo.Request.BlockUser(ctx, userID, targetID, reason)
+}
+
+// UnblockUser убирает пользователя из черного списка
+func (s *RequestService) UnblockUser(userID, targetID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.repo.Request.UnblockUser(ctx, userID, targetID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetUserBlocks возвращает черный список пользователя
+func (s *RequestService) GetUserBlocks(userID int) ([]models.UserBlock, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.repo.Request.GetUserBlocks(ctx, userID)
+}
+
+// GetUserBlockRelations возвращает черный список пользователя и тех, кто внес его в свой черный список.
+// Доступно только администраторам.
+func (s *RequestService) GetUserBlockRelations(adminID, userID int) (*models.UserBlockRelations, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := s.requireAdmin(ctx, adminID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.User.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, models.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	blocking, err := s.repo.Request.GetUserBlocks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	blockedBy, err := s.repo.Request.GetUserBlockedBy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserBlockRelations{
+		UserID:    userID,
+		Blocking:  blocking,
+		BlockedBy: blockedBy,
+	}, nil
+}
+
+// isBlockedByRequester проверяет, внес ли автор заявки волонтера в черный список
+func (s *RequestService) isBlockedByRequester(ctx context.Context, requestID, volunteerID int) (bool, error) {
+	request, err := s.repo.Request.GetRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, models.ErrNotFound
+		}
+		return false, err
+	}
+
+	return s.repo.Request.IsBlockedByAny(ctx, []int{request.RequesterID}, volunteerID)
+}
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- История переходов заявок между статусами
+
+CREATE TABLE IF NOT EXISTS request_status_history (
+  id SERIAL PRIMARY KEY,
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  from_status request_status,
+  to_status request_status NOT NULL,
+  actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+  reason TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_request_status_history_request ON request_status_history(request_id, created_at);
+
+-- Начальная запись для уже существующих заявок
+INSERT INTO request_status_history (request_id, from_status, to_status, actor_id, created_at)
+SELECT id, NULL, status, requester_id, created_at
+FROM help_requests;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_request_status_history_request;
+DROP TABLE IF EXISTS request_status_history;
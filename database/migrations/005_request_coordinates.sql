@@ -0,0 +1,22 @@
+-- +migrate Up
+-- Координаты заявок для поиска заявок рядом с волонтером.
+-- Расстояние считается по формуле гаверсинусов, поэтому PostGIS не требуется.
+
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS location_lat DOUBLE PRECISION;
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS location_lon DOUBLE PRECISION;
+
+ALTER TABLE help_requests ADD CONSTRAINT help_requests_location_lat_check
+  CHECK (location_lat IS NULL OR location_lat BETWEEN -90 AND 90);
+ALTER TABLE help_requests ADD CONSTRAINT help_requests_location_lon_check
+  CHECK (location_lon IS NULL OR location_lon BETWEEN -180 AND 180);
+
+-- Индекс для предварительного отбора по ограничивающему прямоугольнику
+CREATE INDEX idx_help_requests_coordinates ON help_requests(location_lat, location_lon)
+  WHERE location_lat IS NOT NULL AND location_lon IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_help_requests_coordinates;
+ALTER TABLE help_requests DROP CONSTRAINT IF EXISTS help_requests_location_lon_check;
+ALTER TABLE help_requests DROP CONSTRAINT IF EXISTS help_requests_location_lat_check;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS location_lon;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS location_lat;
@@ -0,0 +1,28 @@
+-- +migrate Up
+-- Несколько волонтеров на одну заявку: число мест и закрепления волонтеров
+
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS volunteer_slots INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE help_requests ADD CONSTRAINT help_requests_volunteer_slots_check
+  CHECK (volunteer_slots BETWEEN 1 AND 10);
+
+CREATE TABLE IF NOT EXISTS request_assignments (
+  id SERIAL PRIMARY KEY,
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  volunteer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (request_id, volunteer_id)
+);
+
+CREATE INDEX idx_request_assignments_volunteer ON request_assignments(volunteer_id);
+
+-- Закрепления для заявок, уже взятых волонтерами
+INSERT INTO request_assignments (request_id, volunteer_id, created_at)
+SELECT id, assigned_to, updated_at
+FROM help_requests
+WHERE assigned_to IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_request_assignments_volunteer;
+DROP TABLE IF EXISTS request_assignments;
+ALTER TABLE help_requests DROP CONSTRAINT IF EXISTS help_requests_volunteer_slots_check;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS volunteer_slots;
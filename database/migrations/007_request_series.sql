@@ -0,0 +1,57 @@
+-- +migrate Up
+-- Серии повторяющихся заявок (еженедельная доставка лекарств, продуктов и т.д.)
+
+CREATE TYPE recurrence_frequency AS ENUM ('weekly', 'biweekly', 'monthly');
+CREATE TYPE request_series_status AS ENUM ('active', 'paused', 'ended');
+
+CREATE TABLE IF NOT EXISTS request_series (
+  id SERIAL PRIMARY KEY,
+  requester_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  title VARCHAR(255) NOT NULL,
+  description TEXT,
+  category_id INTEGER REFERENCES request_categories(id),
+  priority request_priority NOT NULL DEFAULT 'medium',
+  location TEXT,
+  location_lat DOUBLE PRECISION,
+  location_lon DOUBLE PRECISION,
+  volunteer_slots INTEGER NOT NULL DEFAULT 1 CHECK (volunteer_slots BETWEEN 1 AND 10),
+  frequency recurrence_frequency NOT NULL,
+  day_of_week SMALLINT CHECK (day_of_week BETWEEN 0 AND 6),
+  day_of_month SMALLINT CHECK (day_of_month BETWEEN 1 AND 28),
+  time_of_day VARCHAR(5) NOT NULL CHECK (time_of_day ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$'),
+  timezone VARCHAR(64) NOT NULL DEFAULT 'Europe/Moscow',
+  starts_on DATE NOT NULL,
+  ends_on DATE,
+  default_volunteer_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+  status request_series_status NOT NULL DEFAULT 'active',
+  next_occurrence_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  CHECK (frequency = 'monthly' OR day_of_week IS NOT NULL),
+  CHECK (frequency <> 'monthly' OR day_of_month IS NOT NULL)
+);
+
+CREATE INDEX idx_request_series_requester ON request_series(requester_id);
+CREATE INDEX idx_request_series_due ON request_series(next_occurrence_at) WHERE status = 'active';
+
+-- Повторения серии - обычные заявки со ссылкой на серию и запланированным временем
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES request_series(id) ON DELETE SET NULL;
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX idx_help_requests_series_occurrence ON help_requests(series_id, scheduled_for)
+  WHERE series_id IS NOT NULL;
+
+CREATE TRIGGER update_request_series_modtime
+  BEFORE UPDATE ON request_series
+  FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_request_series_modtime ON request_series;
+DROP INDEX IF EXISTS idx_help_requests_series_occurrence;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS scheduled_for;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS series_id;
+DROP INDEX IF EXISTS idx_request_series_due;
+DROP INDEX IF EXISTS idx_request_series_requester;
+DROP TABLE IF EXISTS request_series;
+DROP TYPE IF EXISTS request_series_status;
+DROP TYPE IF EXISTS recurrence_frequency;
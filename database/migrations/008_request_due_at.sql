@@ -0,0 +1,43 @@
+-- +migrate Up notransaction
+-- Срок заявки: просроченные заявки без волонтера переходят в статус expired.
+-- Новые значения перечислений нельзя использовать в той же транзакции,
+-- в которой они добавлены, поэтому миграция выполняется без транзакции.
+
+ALTER TYPE request_status ADD VALUE IF NOT EXISTS 'expired';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'request_expired';
+
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS due_at TIMESTAMP WITH TIME ZONE;
+
+-- Поиск просроченных заявок и сортировка "скоро срок" по открытым заявкам
+CREATE INDEX IF NOT EXISTS idx_help_requests_due_at ON help_requests(due_at)
+  WHERE status = 'new' AND due_at IS NOT NULL;
+
+CREATE OR REPLACE VIEW system_stats AS
+SELECT
+    (SELECT COUNT(*) FROM users WHERE role = 'volunteer') AS total_volunteers,
+    (SELECT COUNT(*) FROM help_requests WHERE status = 'completed') AS total_completed_requests,
+    (SELECT COUNT(*) FROM users WHERE role = 'user') AS total_users,
+    (SELECT COUNT(*) FROM help_requests) AS total_requests,
+    (SELECT COUNT(*) FROM partner_organizations) AS total_partners,
+    (SELECT SUM(volunteer_hours) FROM user_stats) AS total_volunteer_hours,
+    (SELECT AVG(rating) FROM request_ratings) AS average_rating,
+    (SELECT COUNT(*) FROM help_requests WHERE status = 'new') AS pending_requests,
+    (SELECT COUNT(*) FROM help_requests WHERE status = 'expired') AS expired_requests;
+
+-- +migrate Down notransaction
+-- Значения перечислений не удаляются: просроченные заявки считаются отмененными
+DROP VIEW IF EXISTS system_stats;
+CREATE VIEW system_stats AS
+SELECT
+    (SELECT COUNT(*) FROM users WHERE role = 'volunteer') AS total_volunteers,
+    (SELECT COUNT(*) FROM help_requests WHERE status = 'completed') AS total_completed_requests,
+    (SELECT COUNT(*) FROM users WHERE role = 'user') AS total_users,
+    (SELECT COUNT(*) FROM help_requests) AS total_requests,
+    (SELECT COUNT(*) FROM partner_organizations) AS total_partners,
+    (SELECT SUM(volunteer_hours) FROM user_stats) AS total_volunteer_hours,
+    (SELECT AVG(rating) FROM request_ratings) AS average_rating,
+    (SELECT COUNT(*) FROM help_requests WHERE status = 'new') AS pending_requests;
+
+UPDATE help_requests SET status = 'cancelled' WHERE status = 'expired';
+DROP INDEX IF EXISTS idx_help_requests_due_at;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,47 @@
+-- +migrate Up
+-- Эскалация заявок, которые долго остаются без волонтера
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'request_escalated';
+
+-- Пороги эскалации по приоритету; правило с категорией важнее общего правила (category_id IS NULL)
+CREATE TABLE IF NOT EXISTS request_escalation_rules (
+  id SERIAL PRIMARY KEY,
+  category_id INTEGER REFERENCES request_categories(id) ON DELETE CASCADE,
+  priority request_priority NOT NULL,
+  threshold_minutes INTEGER NOT NULL CHECK (threshold_minutes > 0),
+  raise_priority BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_request_escalation_rules_scope
+  ON request_escalation_rules ((COALESCE(category_id, 0)), priority);
+
+CREATE TRIGGER update_request_escalation_rules_modtime
+  BEFORE UPDATE ON request_escalation_rules
+  FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+INSERT INTO request_escalation_rules (category_id, priority, threshold_minutes, raise_priority) VALUES
+  (NULL, 'high', 60, FALSE),
+  (NULL, 'medium', 240, TRUE),
+  (NULL, 'low', 1440, TRUE);
+
+-- Журнал эскалаций: почему и когда заявка стала "громче"
+CREATE TABLE IF NOT EXISTS request_escalations (
+  id SERIAL PRIMARY KEY,
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  rule_id INTEGER REFERENCES request_escalation_rules(id) ON DELETE SET NULL,
+  level INTEGER NOT NULL CHECK (level > 0),
+  from_priority request_priority NOT NULL,
+  to_priority request_priority NOT NULL,
+  threshold_minutes INTEGER NOT NULL,
+  waited_minutes INTEGER NOT NULL,
+  notified_count INTEGER NOT NULL DEFAULT 0,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (request_id, level)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS request_escalations;
+DROP TRIGGER IF EXISTS update_request_escalation_rules_modtime ON request_escalation_rules;
+DROP TABLE IF EXISTS request_escalation_rules;
@@ -0,0 +1,32 @@
+-- +migrate Up
+-- Учет времени волонтеров: отметки начала и окончания работы над заявкой
+
+CREATE TABLE IF NOT EXISTS request_time_entries (
+  id SERIAL PRIMARY KEY,
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  volunteer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  checked_in_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  checked_out_at TIMESTAMP WITH TIME ZONE,
+  -- Засчитанное время с учетом ограничения на одну отметку; заполняется при окончании работы
+  minutes INTEGER CHECK (minutes >= 0),
+  corrected_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+  correction_reason TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  CHECK (checked_out_at IS NULL OR checked_out_at >= checked_in_at)
+);
+
+CREATE INDEX idx_request_time_entries_request ON request_time_entries(request_id);
+CREATE INDEX idx_request_time_entries_volunteer ON request_time_entries(volunteer_id);
+
+-- У волонтера может быть только одна незакрытая отметка по заявке
+CREATE UNIQUE INDEX idx_request_time_entries_open
+  ON request_time_entries(request_id, volunteer_id) WHERE checked_out_at IS NULL;
+
+CREATE TRIGGER update_request_time_entries_modtime
+  BEFORE UPDATE ON request_time_entries
+  FOR EACH ROW EXECUTE PROCEDURE update_modified_column();
+
+-- +migrate Down
+DROP TRIGGER IF EXISTS update_request_time_entries_modtime ON request_time_entries;
+DROP TABLE IF EXISTS request_time_entries;
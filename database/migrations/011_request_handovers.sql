@@ -0,0 +1,31 @@
+-- +migrate Up
+-- Отказ волонтера от взятой заявки и передача заявки другому волонтеру
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'request_released';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'request_handover';
+
+CREATE TYPE request_handover_status AS ENUM ('released', 'pending', 'accepted', 'declined', 'cancelled');
+
+-- Журнал отказов волонтеров: released - место освобождено сразу,
+-- pending/accepted/declined/cancelled - передача заявки другому волонтеру
+CREATE TABLE IF NOT EXISTS request_handovers (
+  id SERIAL PRIMARY KEY,
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  from_volunteer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  to_volunteer_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  status request_handover_status NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  resolved_at TIMESTAMP WITH TIME ZONE,
+  CHECK (status = 'released' OR to_volunteer_id IS NOT NULL)
+);
+
+CREATE INDEX idx_request_handovers_request ON request_handovers(request_id);
+
+-- У заявки может быть только одна ожидающая ответа передача
+CREATE UNIQUE INDEX idx_request_handovers_pending
+  ON request_handovers(request_id) WHERE status = 'pending';
+
+-- +migrate Down
+DROP TABLE IF EXISTS request_handovers;
+DROP TYPE IF EXISTS request_handover_status;
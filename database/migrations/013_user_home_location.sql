@@ -0,0 +1,15 @@
+-- +migrate Up
+-- Домашняя точка волонтера для рекомендаций заявок поблизости
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS home_lat DOUBLE PRECISION;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS home_lon DOUBLE PRECISION;
+
+-- Координаты задаются только парой
+ALTER TABLE users ADD CONSTRAINT users_home_location_check
+  CHECK ((home_lat IS NULL AND home_lon IS NULL) OR
+         (home_lat BETWEEN -90 AND 90 AND home_lon BETWEEN -180 AND 180));
+
+-- +migrate Down
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_home_location_check;
+ALTER TABLE users DROP COLUMN IF EXISTS home_lon;
+ALTER TABLE users DROP COLUMN IF EXISTS home_lat;
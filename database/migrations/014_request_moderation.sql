@@ -0,0 +1,21 @@
+-- +migrate Up notransaction
+-- Проверка заявок новых и отмеченных авторов перед публикацией.
+-- Новые значения перечислений используются в частичном индексе,
+-- поэтому миграция выполняется без транзакции.
+
+ALTER TYPE request_status ADD VALUE IF NOT EXISTS 'pending_review';
+ALTER TYPE request_status ADD VALUE IF NOT EXISTS 'changes_requested';
+ALTER TYPE request_status ADD VALUE IF NOT EXISTS 'rejected';
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'request_moderated';
+
+-- Заявки отмеченных администратором пользователей всегда проходят проверку
+ALTER TABLE users ADD COLUMN IF NOT EXISTS requires_review BOOLEAN NOT NULL DEFAULT false;
+
+-- Очередь проверки: самые давние заявки первыми
+CREATE INDEX IF NOT EXISTS idx_help_requests_pending_review ON help_requests(created_at)
+  WHERE status = 'pending_review';
+
+-- +migrate Down
+-- Значения перечислений не удаляются: заявки на проверке остаются в истории
+DROP INDEX IF EXISTS idx_help_requests_pending_review;
+ALTER TABLE users DROP COLUMN IF EXISTS requires_review;
@@ -0,0 +1,32 @@
+-- +migrate Up
+-- Сотрудники партнерских организаций и заявки от имени организаций
+
+-- Заявки от имени организации доступны только проверенным партнерам
+ALTER TABLE partner_organizations ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT false;
+
+CREATE TYPE partner_member_role AS ENUM ('manager', 'staff');
+
+-- Сотрудники организации: manager управляет составом сотрудников, staff создает заявки
+CREATE TABLE IF NOT EXISTS partner_members (
+  partner_id INTEGER NOT NULL REFERENCES partner_organizations(id) ON DELETE CASCADE,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role partner_member_role NOT NULL DEFAULT 'staff',
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (partner_id, user_id)
+);
+
+CREATE INDEX idx_partner_members_user ON partner_members(user_id);
+
+-- Организация, от имени которой создана заявка
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS partner_id INTEGER
+  REFERENCES partner_organizations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_help_requests_partner ON help_requests(partner_id)
+  WHERE partner_id IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_help_requests_partner;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS partner_id;
+DROP TABLE IF EXISTS partner_members;
+DROP TYPE IF EXISTS partner_member_role;
+ALTER TABLE partner_organizations DROP COLUMN IF EXISTS is_verified;
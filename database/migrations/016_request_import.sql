@@ -0,0 +1,16 @@
+-- +migrate Up
+-- Импорт заявок из CSV: внешний идентификатор строки делает повторный импорт идемпотентным
+
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS external_id VARCHAR(100);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_help_requests_external_id ON help_requests(external_id)
+  WHERE external_id IS NOT NULL;
+
+-- Поиск автора импортируемой заявки по телефону без учета форматирования
+CREATE INDEX IF NOT EXISTS idx_users_phone_digits ON users((regexp_replace(phone, '\D', '', 'g')))
+  WHERE phone IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_users_phone_digits;
+DROP INDEX IF EXISTS idx_help_requests_external_id;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,32 @@
+-- +migrate Up
+-- Дневные сводки статистики для главной страницы. Заполняются фоновой задачей,
+-- чтобы статистика не пересчитывалась по всем таблицам на каждый запрос.
+
+CREATE TABLE IF NOT EXISTS stats_daily (
+  day DATE PRIMARY KEY,
+  created_requests INTEGER NOT NULL DEFAULT 0,
+  completed_requests INTEGER NOT NULL DEFAULT 0,
+  expired_requests INTEGER NOT NULL DEFAULT 0,
+  new_users INTEGER NOT NULL DEFAULT 0,
+  new_volunteers INTEGER NOT NULL DEFAULT 0,
+  -- Время волонтеров по заявкам, выполненным в этот день
+  volunteer_minutes INTEGER NOT NULL DEFAULT 0,
+  ratings_count INTEGER NOT NULL DEFAULT 0,
+  ratings_sum INTEGER NOT NULL DEFAULT 0,
+  -- Текущие значения на момент последнего пересчета дня: открытые заявки и партнеры
+  open_requests INTEGER,
+  partners INTEGER,
+  refreshed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Волонтеры, активные в день: взяли заявку, отметили работу или выполнили заявку.
+-- Отдельная таблица нужна, чтобы считать различных волонтеров за неделю и месяц.
+CREATE TABLE IF NOT EXISTS stats_daily_volunteers (
+  day DATE NOT NULL,
+  volunteer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  PRIMARY KEY (day, volunteer_id)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS stats_daily_volunteers;
+DROP TABLE IF EXISTS stats_daily;
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- Ответы на комментарии (один уровень вложенности), редактирование и удаление комментариев.
+-- Удаленный комментарий остается в таблице, чтобы ветка ответов не разрывалась.
+
+ALTER TABLE request_comments
+  ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES request_comments(id) ON DELETE CASCADE,
+  ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE,
+  ADD COLUMN IF NOT EXISTS deleted_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_request_comments_request ON request_comments(request_id, created_at)
+  WHERE parent_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_request_comments_parent ON request_comments(parent_id, created_at)
+  WHERE parent_id IS NOT NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_request_comments_parent;
+DROP INDEX IF EXISTS idx_request_comments_request;
+ALTER TABLE request_comments
+  DROP COLUMN IF EXISTS deleted_by,
+  DROP COLUMN IF EXISTS deleted_at,
+  DROP COLUMN IF EXISTS edited_at,
+  DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,33 @@
+-- +migrate Up notransaction
+-- Личная переписка автора заявки с ее волонтерами. В отличие от комментариев,
+-- сообщения видят только участники заявки и администраторы.
+-- Новое значение перечисления нельзя использовать в той же транзакции,
+-- в которой оно добавлено, поэтому миграция выполняется без транзакции.
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'request_message';
+
+CREATE TABLE IF NOT EXISTS request_messages (
+  id SERIAL PRIMARY KEY,
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  text TEXT NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_messages_request ON request_messages(request_id, id);
+
+-- Отметки прочтения: последнее прочитанное участником сообщение переписки
+CREATE TABLE IF NOT EXISTS request_message_reads (
+  request_id INTEGER NOT NULL REFERENCES help_requests(id) ON DELETE CASCADE,
+  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  last_read_message_id INTEGER NOT NULL,
+  read_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (request_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_message_reads_user ON request_message_reads(user_id);
+
+-- +migrate Down notransaction
+-- Значение перечисления не удаляется: уведомления о сообщениях остаются в истории
+DROP TABLE IF EXISTS request_message_reads;
+DROP TABLE IF EXISTS request_messages;
@@ -0,0 +1,25 @@
+-- +migrate Up
+-- Оценки в обе стороны: автор оценивает волонтеров, волонтеры - автора.
+-- Рейтинг пользователя - байесовское среднее: (C * m + сумма оценок) / (C + число оценок),
+-- где m - априорное среднее (RATING_PRIOR_MEAN), C - его вес (RATING_PRIOR_WEIGHT).
+
+ALTER TABLE user_stats ADD COLUMN IF NOT EXISTS ratings_count INTEGER NOT NULL DEFAULT 0;
+
+-- Отзывы, полученные пользователем, новые первыми
+CREATE INDEX IF NOT EXISTS idx_request_ratings_rated ON request_ratings(rated_id, created_at DESC);
+
+-- Пересчет рейтингов по уже поставленным оценкам со значениями по умолчанию m = 4, C = 5,
+-- чтобы они были сравнимы с рейтингами, которые пересчитывает приложение
+INSERT INTO user_stats (user_id, rating, ratings_count)
+SELECT rr.rated_id,
+  ROUND((5 * 4 + SUM(rr.rating)) / (5 + COUNT(*))::numeric, 2),
+  COUNT(*)
+FROM request_ratings rr
+GROUP BY rr.rated_id
+ON CONFLICT (user_id) DO UPDATE
+SET rating = EXCLUDED.rating,
+  ratings_count = EXCLUDED.ratings_count;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_request_ratings_rated;
+ALTER TABLE user_stats DROP COLUMN IF EXISTS ratings_count;
@@ -0,0 +1,52 @@
+-- +migrate Up
+-- Жалобы на заявки, комментарии и пользователей. Заявка или комментарий, набравшие
+-- REPORT_HIDE_THRESHOLD открытых жалоб, скрываются до решения администратора.
+
+CREATE TYPE abuse_report_target AS ENUM ('request', 'comment', 'user');
+CREATE TYPE abuse_report_reason AS ENUM ('fake', 'spam', 'abuse', 'no_show', 'other');
+CREATE TYPE abuse_report_status AS ENUM ('open', 'dismissed', 'actioned');
+
+CREATE TABLE IF NOT EXISTS abuse_reports (
+  id SERIAL PRIMARY KEY,
+  reporter_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  target_type abuse_report_target NOT NULL,
+  target_id INTEGER NOT NULL,
+  reason abuse_report_reason NOT NULL,
+  details TEXT NOT NULL DEFAULT '',
+  status abuse_report_status NOT NULL DEFAULT 'open',
+  -- Решение администратора: dismiss, hide или suspend
+  action VARCHAR(20),
+  resolution_note TEXT NOT NULL DEFAULT '',
+  resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+  resolved_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Пока жалоба не рассмотрена, пользователь не может пожаловаться на то же еще раз
+CREATE UNIQUE INDEX IF NOT EXISTS idx_abuse_reports_open_reporter
+  ON abuse_reports(target_type, target_id, reporter_id) WHERE status = 'open';
+
+-- Очередь жалоб администратора
+CREATE INDEX IF NOT EXISTS idx_abuse_reports_status ON abuse_reports(status, created_at);
+
+-- Скрытые заявки и комментарии; hidden_by пуст, если скрыто автоматически по жалобам
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE help_requests ADD COLUMN IF NOT EXISTS hidden_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE request_comments ADD COLUMN IF NOT EXISTS hidden_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE request_comments ADD COLUMN IF NOT EXISTS hidden_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- Заблокированный администратором пользователь не может создавать заявки, писать и брать заявки
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_by;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
+ALTER TABLE request_comments DROP COLUMN IF EXISTS hidden_by;
+ALTER TABLE request_comments DROP COLUMN IF EXISTS hidden_at;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS hidden_by;
+ALTER TABLE help_requests DROP COLUMN IF EXISTS hidden_at;
+DROP TABLE IF EXISTS abuse_reports;
+DROP TYPE IF EXISTS abuse_report_status;
+DROP TYPE IF EXISTS abuse_report_reason;
+DROP TYPE IF EXISTS abuse_report_target;
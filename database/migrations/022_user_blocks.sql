@@ -0,0 +1,18 @@
+-- +migrate Up
+-- Черный список: пользователь, попавший в черный список автора, не может брать его заявки,
+-- не видит их в рекомендациях и не может писать ему в комментариях и переписке по заявкам.
+
+CREATE TABLE IF NOT EXISTS user_blocks (
+  blocker_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  blocked_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (blocker_id, blocked_id),
+  CHECK (blocker_id <> blocked_id)
+);
+
+-- Проверка, кто заблокировал пользователя
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS user_blocks;